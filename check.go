@@ -0,0 +1,96 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MeroFuruya/dotenv/dotenv"
+)
+
+// RuleFlags collects comma-separated rule names from repeated flags.
+type RuleFlags []string
+
+// String is an implementation of the flag.Value interface
+func (r *RuleFlags) String() string {
+	return strings.Join(*r, ",")
+}
+
+// Set is an implementation of the flag.Value interface
+func (r *RuleFlags) Set(value string) error {
+	for _, rule := range strings.Split(value, ",") {
+		rule = strings.TrimSpace(rule)
+		if !isLintRule(rule) {
+			return fmt.Errorf("unknown rule %q (available: %s)", rule, strings.Join(dotenv.LintRules, ", "))
+		}
+		*r = append(*r, rule)
+	}
+	return nil
+}
+
+func isLintRule(name string) bool {
+	for _, rule := range dotenv.LintRules {
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckCommand implements `dotenv check [options] [file...]`. It lints the
+// given files, or the dotenv file found by the search flags, and returns 1
+// if any problem was found.
+func CheckCommand(args []string) int {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dotenv check [options] [file...]")
+		fmt.Fprintln(fs.Output(), "Rules:", strings.Join(dotenv.LintRules, ", "))
+		fs.PrintDefaults()
+	}
+	var search SearchFlags
+	search.Register(fs)
+	var enable, disable RuleFlags
+	fs.Var(&enable, "enable", "Only check these rules (comma-separated, can be specified multiple times) (default: all rules)")
+	fs.Var(&disable, "disable", "Do not check these rules (comma-separated, can be specified multiple times)")
+	fs.Parse(args)
+
+	rules := dotenv.AllRules()
+	if len(enable) > 0 {
+		rules = dotenv.RuleSet{}
+		for _, rule := range enable {
+			rules[rule] = true
+		}
+	}
+	for _, rule := range disable {
+		delete(rules, rule)
+	}
+
+	dotenvFiles := fs.Args()
+	if len(dotenvFiles) == 0 {
+		var ok bool
+		if dotenvFiles, ok = search.Files(); !ok {
+			return 1
+		}
+	}
+
+	problems := 0
+	for _, dotenvFile := range dotenvFiles {
+		content, err := os.ReadFile(dotenvFile)
+		if err != nil {
+			Error("Error reading dotenv file:", err)
+			problems++
+			continue
+		}
+		for _, diagnostic := range dotenv.Lint(dotenvFile, content, rules) {
+			fmt.Println(diagnostic)
+			problems++
+		}
+	}
+
+	if problems > 0 {
+		return 1
+	}
+	Log("No problems found")
+	return 0
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/MeroFuruya/dotenv/dotenv"
+)
+
+// DiffCommand implements `dotenv diff [options] [file [file]]`. It compares
+// two dotenv files, or the environment with one file (by default the one
+// found by the search flags), showing what loading the file would change.
+// It returns 1 if there are differences and 2 if a file cannot be loaded.
+func DiffCommand(args []string) int {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dotenv diff [options] [file [file]]")
+		fmt.Fprintln(fs.Output(), "With a single file, the environment is compared with the variables defined in it.")
+		fs.PrintDefaults()
+	}
+	var search SearchFlags
+	search.Register(fs)
+	showValues := fs.Bool("show-values", false, "Show the values of variables that differ instead of masking them (default: false)")
+	jsonOutput := fs.Bool("json", false, "Print the differences as JSON (default: false)")
+	positional := ParseArgs(fs, args)
+	if len(positional) > 2 {
+		fs.Usage()
+		return 2
+	}
+
+	var first []dotenv.Variable
+	var ok bool
+	switch len(positional) {
+	case 0:
+		if first, ok = search.Load(); !ok {
+			return 2
+		}
+	default:
+		if first, ok = parseFiles(positional[:1], nil); !ok {
+			return 2
+		}
+	}
+
+	var before, after []dotenv.Variable
+	if len(positional) == 2 {
+		before = first
+		if after, ok = parseFiles(positional[1:], nil); !ok {
+			return 2
+		}
+	} else {
+		// Variables of the file that are not set are added by loading it.
+		// Only look at the variables the file defines, the environment has
+		// many more
+		after = first
+		defined := make(map[string]bool, len(after))
+		for _, variable := range after {
+			defined[variable.Name] = true
+		}
+		for _, variable := range dotenv.EnvironVariables(os.Environ()) {
+			if defined[variable.Name] {
+				before = append(before, variable)
+			}
+		}
+	}
+
+	differences := dotenv.Compare(before, after)
+	if *jsonOutput {
+		printDifferencesJSON(differences, *showValues)
+	} else {
+		printDifferences(differences, *showValues)
+	}
+
+	if len(differences) > 0 {
+		return 1
+	}
+	Log("No differences found")
+	return 0
+}
+
+// printDifferences prints one line per difference, prefixed with +, - or ~.
+func printDifferences(differences []dotenv.Difference, showValues bool) {
+	for _, difference := range differences {
+		switch {
+		case !showValues:
+			fmt.Printf("%c %s\n", changeSymbol(difference.Kind), difference.Name)
+		case difference.Kind == dotenv.Added:
+			fmt.Printf("+ %s=%q\n", difference.Name, difference.New.Value)
+		case difference.Kind == dotenv.Removed:
+			fmt.Printf("- %s=%q\n", difference.Name, difference.Old.Value)
+		default:
+			fmt.Printf("~ %s: %q -> %q\n", difference.Name, difference.Old.Value, difference.New.Value)
+		}
+	}
+}
+
+func changeSymbol(kind dotenv.ChangeKind) rune {
+	switch kind {
+	case dotenv.Added:
+		return '+'
+	case dotenv.Removed:
+		return '-'
+	default:
+		return '~'
+	}
+}
+
+// jsonDefinition is one side of a difference in the JSON output.
+type jsonDefinition struct {
+	Value *string `json:"value,omitempty"`
+	File  string  `json:"file,omitempty"`
+	Line  int     `json:"line,omitempty"`
+}
+
+type jsonDifference struct {
+	Name   string          `json:"name"`
+	Change string          `json:"change"`
+	Old    *jsonDefinition `json:"old,omitempty"`
+	New    *jsonDefinition `json:"new,omitempty"`
+}
+
+// printDifferencesJSON prints the differences as a JSON array. Values are
+// left out unless showValues is set.
+func printDifferencesJSON(differences []dotenv.Difference, showValues bool) {
+	definition := func(variable dotenv.Variable) *jsonDefinition {
+		result := &jsonDefinition{File: variable.File, Line: variable.Line}
+		if showValues {
+			value := variable.Value
+			result.Value = &value
+		}
+		return result
+	}
+
+	output := make([]jsonDifference, 0, len(differences))
+	for _, difference := range differences {
+		entry := jsonDifference{Name: difference.Name, Change: difference.Kind.String()}
+		if difference.Kind != dotenv.Added {
+			entry.Old = definition(difference.Old)
+		}
+		if difference.Kind != dotenv.Removed {
+			entry.New = definition(difference.New)
+		}
+		output = append(output, entry)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(output)
+}
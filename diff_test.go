@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDiffCommand(t *testing.T) {
+	writeFiles(t, map[string]string{
+		"a.env":   "HOST=localhost\nPORT=3000\nDEBUG=true\n",
+		"b.env":   "HOST=example.com\nPORT=3000\nTOKEN=s3cr3t\n",
+		"bad.env": "HOST=\"localhost\n",
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		status   int
+	}{
+		{"same", []string{"a.env", "a.env"}, "", 0},
+		{"different", []string{"a.env", "b.env"}, "- DEBUG\n~ HOST\n+ TOKEN\n", 1},
+		{"values", []string{"-show-values", "a.env", "b.env"}, "- DEBUG=\"true\"\n~ HOST: \"localhost\" -> \"example.com\"\n+ TOKEN=\"s3cr3t\"\n", 1},
+		{"missing file", []string{"a.env", "missing.env"}, "", 2},
+		{"invalid file", []string{"bad.env", "a.env"}, "", 2},
+		{"too many files", []string{"a.env", "a.env", "a.env"}, "", 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, status := captureStdout(t, func() int { return DiffCommand(test.args) })
+			if output != test.expected || status != test.status {
+				t.Errorf("DiffCommand(%q) printed %q and returned %d, want %q and %d", test.args, output, status, test.expected, test.status)
+			}
+		})
+	}
+}
+
+func TestDiffCommandEnvironment(t *testing.T) {
+	writeFiles(t, map[string]string{".env": "DOTENV_TEST_HOST=example.com\nDOTENV_TEST_PORT=3000\nDOTENV_TEST_NEW=1\n"})
+	t.Setenv("DOTENV_TEST_HOST", "localhost")
+	t.Setenv("DOTENV_TEST_PORT", "3000")
+	t.Setenv("DOTENV_TEST_OTHER", "ignored")
+
+	// Relative to the environment, loading the file adds and changes
+	// variables
+	expected := "~ DOTENV_TEST_HOST: \"localhost\" -> \"example.com\"\n+ DOTENV_TEST_NEW=\"1\"\n"
+	output, status := captureStdout(t, func() int { return DiffCommand([]string{"-show-values"}) })
+	if output != expected || status != 1 {
+		t.Errorf("DiffCommand() printed %q and returned %d, want %q and 1", output, status, expected)
+	}
+
+	t.Setenv("DOTENV_TEST_HOST", "example.com")
+	t.Setenv("DOTENV_TEST_NEW", "1")
+	output, status = captureStdout(t, func() int { return DiffCommand(nil) })
+	if output != "" || status != 0 {
+		t.Errorf("DiffCommand() printed %q and returned %d, want no output and 0", output, status)
+	}
+}
+
+func TestDiffCommandJSON(t *testing.T) {
+	writeFiles(t, map[string]string{
+		"a.env": "HOST=localhost\nDEBUG=true\n",
+		"b.env": "HOST=example.com\nTOKEN=s3cr3t\n",
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []any
+	}{
+		{"masked", []string{"-json", "a.env", "b.env"}, []any{
+			map[string]any{"name": "DEBUG", "change": "removed", "old": map[string]any{"file": "a.env", "line": 2.0}},
+			map[string]any{"name": "HOST", "change": "changed", "old": map[string]any{"file": "a.env", "line": 1.0}, "new": map[string]any{"file": "b.env", "line": 1.0}},
+			map[string]any{"name": "TOKEN", "change": "added", "new": map[string]any{"file": "b.env", "line": 2.0}},
+		}},
+		{"values", []string{"-json", "-show-values", "a.env", "b.env"}, []any{
+			map[string]any{"name": "DEBUG", "change": "removed", "old": map[string]any{"file": "a.env", "line": 2.0, "value": "true"}},
+			map[string]any{"name": "HOST", "change": "changed", "old": map[string]any{"file": "a.env", "line": 1.0, "value": "localhost"}, "new": map[string]any{"file": "b.env", "line": 1.0, "value": "example.com"}},
+			map[string]any{"name": "TOKEN", "change": "added", "new": map[string]any{"file": "b.env", "line": 2.0, "value": "s3cr3t"}},
+		}},
+		{"no differences", []string{"-json", "a.env", "a.env"}, []any{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, _ := captureStdout(t, func() int { return DiffCommand(test.args) })
+			var differences []any
+			if err := json.Unmarshal([]byte(output), &differences); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, output)
+			}
+			if !reflect.DeepEqual(differences, test.expected) {
+				t.Errorf("DiffCommand(%q) printed %s, want %v", test.args, output, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,475 @@
+package dotenv
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// QuoteStyle is the way a value is quoted in the source.
+type QuoteStyle int
+
+const (
+	// Unquoted values end at a # or the end of the line.
+	Unquoted QuoteStyle = iota
+	// SingleQuoted values ('...') are taken literally.
+	SingleQuoted
+	// DoubleQuoted values ("...") support escape sequences and interpolation.
+	DoubleQuoted
+	// TripleSingleQuoted values ('''...''') may span lines and are taken
+	// literally.
+	TripleSingleQuoted
+	// TripleDoubleQuoted values ("""...""") may span lines and support escape
+	// sequences and interpolation.
+	TripleDoubleQuoted
+)
+
+// Delimiter returns the quote characters enclosing a value of this style.
+func (q QuoteStyle) Delimiter() string {
+	switch q {
+	case SingleQuoted:
+		return `'`
+	case DoubleQuoted:
+		return `"`
+	case TripleSingleQuoted:
+		return `'''`
+	case TripleDoubleQuoted:
+		return `"""`
+	default:
+		return ""
+	}
+}
+
+// Document is a concrete syntax tree of a dotenv file. It keeps comments,
+// blank lines, spacing and quoting, so that String reproduces the parsed
+// input byte for byte.
+type Document struct {
+	Nodes []Node
+}
+
+// Node is a part of a Document spanning one or more complete lines.
+type Node interface {
+	// Text returns the source text of the node including its line break.
+	Text() string
+}
+
+// Blank is an empty or whitespace-only line.
+type Blank struct {
+	Raw string
+}
+
+// Text returns the source text of the line.
+func (b *Blank) Text() string {
+	return b.Raw
+}
+
+// Comment is a line whose first non-blank character is #.
+type Comment struct {
+	Raw string
+}
+
+// Text returns the source text of the line.
+func (c *Comment) Text() string {
+	return c.Raw
+}
+
+// Unparsed is a line that does not define a variable, either because it has
+// no '=' (the parser ignores these) or because it is invalid.
+type Unparsed struct {
+	Raw string
+}
+
+// Text returns the source text of the line.
+func (u *Unparsed) Text() string {
+	return u.Raw
+}
+
+// Entry is a variable definition. Concatenating its fields in order gives
+// its source text.
+type Entry struct {
+	// Indent is the whitespace before the definition.
+	Indent string
+	// Export is the "export" keyword including the whitespace after it, or
+	// empty.
+	Export string
+	Key    string
+	// Separator is the equals sign including the whitespace around it.
+	Separator string
+	Quote     QuoteStyle
+	// RawValue is the value as written between the quotes, with escape
+	// sequences and references left as they are.
+	RawValue string
+	// Suffix is the text after the value, such as whitespace and an inline
+	// comment.
+	Suffix string
+	// Newline is the line break ending the entry, empty at the end of a file
+	// without a final newline.
+	Newline string
+}
+
+// Text returns the source text of the entry.
+func (e *Entry) Text() string {
+	delimiter := e.Quote.Delimiter()
+	return e.Indent + e.Export + e.Key + e.Separator + delimiter + e.RawValue + delimiter + e.Suffix + e.Newline
+}
+
+// String returns the source text of the document.
+func (d *Document) String() string {
+	var result strings.Builder
+	for _, node := range d.Nodes {
+		result.WriteString(node.Text())
+	}
+	return result.String()
+}
+
+// WriteTo writes the source text of the document to w.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	n, err := io.WriteString(w, d.String())
+	return int64(n), err
+}
+
+// Entries returns the variable definitions of the document in order.
+func (d *Document) Entries() []*Entry {
+	var entries []*Entry
+	for _, node := range d.Nodes {
+		if entry, ok := node.(*Entry); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Lookup returns the last definition of key, or nil if there is none.
+func (d *Document) Lookup(key string) *Entry {
+	for i := len(d.Nodes) - 1; i >= 0; i-- {
+		if entry, ok := d.Nodes[i].(*Entry); ok && entry.Key == key {
+			return entry
+		}
+	}
+	return nil
+}
+
+// Variables parses the document with the regular Parser and returns its
+// variables.
+func (d *Document) Variables() ([]Variable, error) {
+	return Parse(strings.NewReader(d.String()))
+}
+
+// ParseDocument reads r into a Document. It never fails on invalid syntax;
+// lines that cannot be parsed become Unparsed nodes.
+func ParseDocument(r io.Reader) (*Document, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := splitLinesKeepEnds(string(content))
+	doc := &Document{}
+	for i := 0; i < len(lines); i++ {
+		node, consumed := parseNode(lines[i:])
+		doc.Nodes = append(doc.Nodes, node)
+		i += consumed - 1
+	}
+	return doc, nil
+}
+
+// splitLinesKeepEnds splits content after every \n.
+func splitLinesKeepEnds(content string) []string {
+	var lines []string
+	for content != "" {
+		end := strings.IndexByte(content, '\n') + 1
+		if end == 0 {
+			end = len(content)
+		}
+		lines = append(lines, content[:end])
+		content = content[end:]
+	}
+	return lines
+}
+
+// cutNewline splits the line break off the end of line.
+func cutNewline(line string) (content, newline string) {
+	if strings.HasSuffix(line, "\r\n") {
+		return line[:len(line)-2], "\r\n"
+	}
+	if strings.HasSuffix(line, "\n") {
+		return line[:len(line)-1], "\n"
+	}
+	return line, ""
+}
+
+// parseNode parses the node starting at lines[0] and returns it together
+// with the number of lines it spans. It follows the same rules as Parser.
+func parseNode(lines []string) (Node, int) {
+	raw := lines[0]
+	content, newline := cutNewline(raw)
+	trimmed := strings.TrimSpace(content)
+
+	if trimmed == "" {
+		return &Blank{Raw: raw}, 1
+	}
+	if trimmed[0] == '#' {
+		return &Comment{Raw: raw}, 1
+	}
+
+	entry := &Entry{Newline: newline}
+	rest := strings.TrimLeftFunc(content, unicode.IsSpace)
+	entry.Indent = content[:len(content)-len(rest)]
+
+	if strings.HasPrefix(rest, "export ") {
+		afterExport := strings.TrimLeftFunc(rest[len("export "):], unicode.IsSpace)
+		entry.Export = rest[:len(rest)-len(afterExport)]
+		rest = afterExport
+	}
+
+	eqIndex := strings.IndexByte(rest, '=')
+	if eqIndex == -1 {
+		return &Unparsed{Raw: raw}, 1
+	}
+	keyPart := strings.TrimRightFunc(rest[:eqIndex], unicode.IsSpace)
+	if !isValidVariableName(keyPart) {
+		return &Unparsed{Raw: raw}, 1
+	}
+	entry.Key = keyPart
+	valuePart := strings.TrimLeftFunc(rest[eqIndex+1:], unicode.IsSpace)
+	entry.Separator = rest[len(keyPart) : len(rest)-len(valuePart)]
+
+	switch {
+	case strings.HasPrefix(valuePart, `"""`) || strings.HasPrefix(valuePart, `'''`):
+		return parseMultilineEntry(entry, valuePart, lines)
+	case strings.HasPrefix(valuePart, `"`) || strings.HasPrefix(valuePart, `'`):
+		end := closingQuote(valuePart)
+		if end == -1 {
+			return &Unparsed{Raw: raw}, 1
+		}
+		entry.Quote = DoubleQuoted
+		if valuePart[0] == '\'' {
+			entry.Quote = SingleQuoted
+		}
+		entry.RawValue = valuePart[1:end]
+		entry.Suffix = valuePart[end+1:]
+	default:
+		value, _, _ := strings.Cut(valuePart, "#")
+		entry.RawValue = strings.TrimRightFunc(value, unicode.IsSpace)
+		entry.Suffix = valuePart[len(entry.RawValue):]
+	}
+	return entry, 1
+}
+
+// parseMultilineEntry completes entry with the triple-quoted value starting
+// at valuePart, which is part of lines[0].
+func parseMultilineEntry(entry *Entry, valuePart string, lines []string) (Node, int) {
+	delimiter := valuePart[:3]
+	entry.Quote = TripleSingleQuoted
+	if delimiter == `"""` {
+		entry.Quote = TripleDoubleQuoted
+	}
+
+	// Trailing whitespace after the value was trimmed by the parser, so it
+	// cannot be part of the closing delimiter; keep it in the suffix
+	first := strings.TrimRightFunc(valuePart[3:], unicode.IsSpace)
+	if strings.TrimSpace(first) != "" && strings.HasSuffix(first, delimiter) {
+		// Single line triple-quoted string
+		entry.RawValue = first[:len(first)-3]
+		entry.Suffix = valuePart[3+len(first):]
+		return entry, 1
+	}
+
+	var value strings.Builder
+	value.WriteString(valuePart[3:])
+	value.WriteString(entry.Newline)
+	for i := 1; i < len(lines); i++ {
+		content, newline := cutNewline(lines[i])
+		if strings.HasSuffix(content, delimiter) {
+			value.WriteString(content[:len(content)-3])
+			entry.RawValue = value.String()
+			entry.Newline = newline
+			return entry, i + 1
+		}
+		value.WriteString(lines[i])
+	}
+	return &Unparsed{Raw: lines[0]}, 1
+}
+
+// closingQuote returns the index of the quote closing the quoted value at
+// the start of valuePart, or -1 if it is not closed on this line.
+func closingQuote(valuePart string) int {
+	quote := valuePart[0]
+	for i := 1; i < len(valuePart); i++ {
+		switch {
+		case valuePart[i] == quote:
+			return i
+		case valuePart[i] == '\\' && i+1 < len(valuePart):
+			// Double quotes escape anything, single quotes only \' and \\
+			if quote == '"' || valuePart[i+1] == '\'' || valuePart[i+1] == '\\' {
+				i++
+			}
+		}
+	}
+	return -1
+}
+
+// EncodeValue returns the raw text that the parser reads back as value when
+// it is quoted with style. It reports false if style cannot represent value.
+func EncodeValue(value string, style QuoteStyle) (string, bool) {
+	switch style {
+	case Unquoted:
+		if strings.ContainsAny(value, "#$\\\"'`") || strings.IndexFunc(value, unicode.IsSpace) != -1 || hasControl(value, "") {
+			return "", false
+		}
+		return value, true
+	case SingleQuoted:
+		if hasControl(value, "\t") {
+			return "", false
+		}
+		return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value), true
+	case DoubleQuoted:
+		return escapeDoubleQuoted(value, false), true
+	case TripleSingleQuoted:
+		if strings.Contains(value, `'''`) || hasControl(value, "\t\n") {
+			return "", false
+		}
+		// Start the content on a new line, so it does not matter whether
+		// value begins with whitespace
+		return "\n" + value, true
+	case TripleDoubleQuoted:
+		return "\n" + escapeDoubleQuoted(value, true), true
+	}
+	return "", false
+}
+
+// MinimalQuote returns the simplest quote style that can represent value:
+// no quotes if possible, then single quotes, then triple single quotes for
+// multiline text and double quotes for everything else.
+func MinimalQuote(value string) QuoteStyle {
+	for _, style := range []QuoteStyle{Unquoted, SingleQuoted, TripleSingleQuoted} {
+		if _, ok := EncodeValue(value, style); ok {
+			return style
+		}
+	}
+	return DoubleQuoted
+}
+
+// hasControl reports whether value contains control characters other than
+// those in allowed.
+func hasControl(value, allowed string) bool {
+	for _, char := range value {
+		if unicode.IsControl(char) && !strings.ContainsRune(allowed, char) {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeDoubleQuoted escapes value for double quotes. Every quote and dollar
+// sign is escaped, so the result never contains a closing delimiter or a
+// reference. Line feeds are kept if multiline is set.
+func escapeDoubleQuoted(value string, multiline bool) string {
+	var result strings.Builder
+	for _, char := range value {
+		switch char {
+		case '\\', '"', '$':
+			result.WriteByte('\\')
+			result.WriteRune(char)
+		case '\n':
+			if multiline {
+				result.WriteByte('\n')
+			} else {
+				result.WriteString(`\n`)
+			}
+		case '\r':
+			result.WriteString(`\r`)
+		case '\t':
+			result.WriteString(`\t`)
+		case '\f':
+			result.WriteString(`\f`)
+		case '\b':
+			result.WriteString(`\b`)
+		default:
+			if unicode.IsControl(char) {
+				fmt.Fprintf(&result, `\u%04x`, char)
+			} else {
+				result.WriteRune(char)
+			}
+		}
+	}
+	return result.String()
+}
+
+// SetValue changes the value of the entry. The current quote style is kept
+// if it can represent value, otherwise the minimal one is used.
+func (e *Entry) SetValue(value string) {
+	raw, ok := EncodeValue(value, e.Quote)
+	if !ok {
+		e.Quote = MinimalQuote(value)
+		raw, _ = EncodeValue(value, e.Quote)
+	}
+	e.RawValue = raw
+}
+
+// Set changes the value of the last definition of key, or appends a new
+// definition at the end of the document.
+func (d *Document) Set(key, value string) {
+	if entry := d.Lookup(key); entry != nil {
+		entry.SetValue(value)
+		return
+	}
+
+	newline := d.newline()
+	if len(d.Nodes) > 0 {
+		// Make sure the new entry starts on a line of its own
+		switch last := d.Nodes[len(d.Nodes)-1].(type) {
+		case *Entry:
+			if last.Newline == "" {
+				last.Newline = newline
+			}
+		case *Blank:
+			last.Raw = endLine(last.Raw, newline)
+		case *Comment:
+			last.Raw = endLine(last.Raw, newline)
+		case *Unparsed:
+			last.Raw = endLine(last.Raw, newline)
+		}
+	}
+
+	entry := &Entry{Key: key, Separator: "=", Quote: MinimalQuote(value), Newline: newline}
+	entry.RawValue, _ = EncodeValue(value, entry.Quote)
+	d.Nodes = append(d.Nodes, entry)
+}
+
+// endLine appends newline to line unless it already ends with a line break.
+func endLine(line, newline string) string {
+	if strings.HasSuffix(line, "\n") {
+		return line
+	}
+	return line + newline
+}
+
+// Unset removes every definition of key and reports whether there was one.
+func (d *Document) Unset(key string) bool {
+	nodes := d.Nodes[:0]
+	removed := false
+	for _, node := range d.Nodes {
+		if entry, ok := node.(*Entry); ok && entry.Key == key {
+			removed = true
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	d.Nodes = nodes
+	return removed
+}
+
+// newline returns the line break used by the document, "\n" by default.
+func (d *Document) newline() string {
+	for _, node := range d.Nodes {
+		if text := node.Text(); strings.HasSuffix(text, "\n") {
+			if strings.HasSuffix(text, "\r\n") {
+				return "\r\n"
+			}
+			return "\n"
+		}
+	}
+	return "\n"
+}
@@ -0,0 +1,221 @@
+package dotenv
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDocumentRoundTrip(t *testing.T) {
+	testEnv, err := os.ReadFile("../test.env")
+	if err != nil {
+		t.Fatalf("Failed to read test.env: %v", err)
+	}
+
+	inputs := map[string]string{
+		"test.env":           string(testEnv),
+		"empty":              "",
+		"no final newline":   "A=1\nB=2",
+		"crlf":               "# comment\r\nA=1\r\nB=\"\"\"\r\nline\r\n\"\"\"\r\n\r\n",
+		"spacing":            "  export   KEY  =  value   # comment  \n\tTAB\t=\t'x'\t\n",
+		"unterminated":       "A=\"open\nB='''\nnever closed\nC=3\n",
+		"invalid":            "123=4\nNO_EQUALS\n=empty\n",
+		"whitespace only":    "   \n\t\n",
+		"single line triple": "A=\"\"\"one line\"\"\"  \nB='''raw'''\n",
+	}
+
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			doc, err := ParseDocument(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("ParseDocument() error = %v", err)
+			}
+			if output := doc.String(); output != input {
+				t.Errorf("String() = %q, want %q", output, input)
+			}
+		})
+	}
+}
+
+func TestParseDocumentNodes(t *testing.T) {
+	input := "# Database\n" +
+		"export DATABASE_URL = \"postgres://${HOST}/db\" # inline\n" +
+		"\n" +
+		"NO_EQUALS\n" +
+		"KEY='''\n" +
+		"line 1\n" +
+		"'''\n" +
+		"PLAIN=some value   # comment"
+
+	doc, err := ParseDocument(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+
+	expected := []Node{
+		&Comment{Raw: "# Database\n"},
+		&Entry{Export: "export ", Key: "DATABASE_URL", Separator: " = ", Quote: DoubleQuoted,
+			RawValue: "postgres://${HOST}/db", Suffix: " # inline", Newline: "\n"},
+		&Blank{Raw: "\n"},
+		&Unparsed{Raw: "NO_EQUALS\n"},
+		&Entry{Key: "KEY", Separator: "=", Quote: TripleSingleQuoted, RawValue: "\nline 1\n", Newline: "\n"},
+		&Entry{Key: "PLAIN", Separator: "=", RawValue: "some value", Suffix: "   # comment"},
+	}
+
+	if !reflect.DeepEqual(doc.Nodes, expected) {
+		t.Errorf("ParseDocument() nodes differ")
+		for i, node := range doc.Nodes {
+			t.Logf("node %d: %#v", i, node)
+		}
+	}
+
+	if entry := doc.Lookup("KEY"); entry == nil || entry.RawValue != "\nline 1\n" {
+		t.Errorf("Lookup(KEY) = %#v", entry)
+	}
+	if entry := doc.Lookup("MISSING"); entry != nil {
+		t.Errorf("Lookup(MISSING) = %#v, want nil", entry)
+	}
+	if entries := doc.Entries(); len(entries) != 3 {
+		t.Errorf("Entries() returned %d entries, want 3", len(entries))
+	}
+}
+
+func TestDocumentVariables(t *testing.T) {
+	input := "A=1\n# comment\nB=\"${A}2\"\n"
+
+	doc, err := ParseDocument(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+
+	doc.Lookup("A").RawValue = "one"
+	variables, err := doc.Variables()
+	if err != nil {
+		t.Fatalf("Variables() error = %v", err)
+	}
+
+	expected := []Variable{
+		{Name: "A", Value: "one", Line: 1},
+		{Name: "B", Value: "one2", Line: 3},
+	}
+	if !reflect.DeepEqual(variables, expected) {
+		t.Errorf("Variables() = %v, want %v", variables, expected)
+	}
+}
+
+func TestEncodeValueRoundTrip(t *testing.T) {
+	values := []string{
+		"",
+		"plain",
+		"with space",
+		" leading and trailing ",
+		"hash # inside",
+		`single ' quote`,
+		`double " quote`,
+		`back\slash \n not a newline`,
+		"$HOME and ${HOME} and $$",
+		"tab\tinside",
+		"line 1\nline 2",
+		"line 1\nline 2\n",
+		"\nleading newline",
+		"ends with quote '",
+		`ends with quote "`,
+		`triple ''' and """`,
+		"carriage\r\nreturn",
+		"bell\a",
+		"unicode ✓",
+	}
+	styles := []QuoteStyle{Unquoted, SingleQuoted, DoubleQuoted, TripleSingleQuoted, TripleDoubleQuoted}
+
+	for _, value := range values {
+		for _, style := range styles {
+			raw, ok := EncodeValue(value, style)
+			if !ok {
+				continue
+			}
+			delimiter := style.Delimiter()
+			input := "KEY=" + delimiter + raw + delimiter + "\n"
+			variables, err := Parse(strings.NewReader(input))
+			if err != nil {
+				t.Errorf("EncodeValue(%q, %v): parsing %q failed: %v", value, style, input, err)
+				continue
+			}
+			if len(variables) != 1 || variables[0].Value != value {
+				t.Errorf("EncodeValue(%q, %v): %q parsed as %v", value, style, input, variables)
+			}
+		}
+	}
+}
+
+func TestMinimalQuote(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected QuoteStyle
+	}{
+		{"", Unquoted},
+		{"postgres://localhost:5432/db", Unquoted},
+		{"with space", SingleQuoted},
+		{"$HOME", SingleQuoted},
+		{"line 1\nline 2", TripleSingleQuoted},
+		{"a'''b\nc", DoubleQuoted},
+		{"carriage\rreturn", DoubleQuoted},
+	}
+
+	for _, tt := range tests {
+		if style := MinimalQuote(tt.value); style != tt.expected {
+			t.Errorf("MinimalQuote(%q) = %v, want %v", tt.value, style, tt.expected)
+		}
+	}
+}
+
+func TestDocumentSetUnset(t *testing.T) {
+	input := "# comment\r\n" +
+		"A=1\r\n" +
+		"B=\"old\" # inline\r\n" +
+		"C='x'\r\n" +
+		"A=2\r\n" +
+		"# trailing comment"
+
+	doc, err := ParseDocument(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+
+	doc.Set("B", `new "value"`)
+	doc.Set("C", "multi\nline")
+	doc.Set("A", "3")
+	doc.Set("D", "with space")
+	if !doc.Unset("C") {
+		t.Errorf("Unset(C) = false, want true")
+	}
+	if doc.Unset("MISSING") {
+		t.Errorf("Unset(MISSING) = true, want false")
+	}
+
+	expected := "# comment\r\n" +
+		"A=1\r\n" +
+		"B=\"new \\\"value\\\"\" # inline\r\n" +
+		"A=3\r\n" +
+		"# trailing comment\r\n" +
+		"D='with space'\r\n"
+	if output := doc.String(); output != expected {
+		t.Errorf("String() = %q, want %q", output, expected)
+	}
+
+	doc.Set("C", "multi\nline")
+	if entry := doc.Lookup("C"); entry.Quote != TripleSingleQuoted {
+		t.Errorf("Set(C) quote = %v, want TripleSingleQuoted", entry.Quote)
+	}
+	variables, err := doc.Variables()
+	if err != nil {
+		t.Fatalf("Variables() error = %v", err)
+	}
+	values := make(map[string]string)
+	for _, variable := range variables {
+		values[variable.Name] = variable.Value
+	}
+	if values["B"] != `new "value"` || values["C"] != "multi\nline" || values["A"] != "3" {
+		t.Errorf("Variables() = %v", variables)
+	}
+}
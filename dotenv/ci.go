@@ -0,0 +1,103 @@
+package dotenv
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// gitlabDotenvLimit is the largest dotenv report GitLab accepts by default.
+const gitlabDotenvLimit = 5 * 1024
+
+// renderGitHub renders a file for $GITHUB_ENV in GitHub Actions. Every
+// variable is written as a NAME<<DELIMITER block, so values can span lines.
+// The delimiters are random so that values cannot end a block early.
+func renderGitHub(variables []Variable, _ RenderOptions) (string, error) {
+	var result strings.Builder
+	for _, variable := range variables {
+		delimiter, err := githubDelimiter(variable.Value)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&result, "%s<<%s\n%s\n%s\n", variable.Name, delimiter, variable.Value, delimiter)
+	}
+	return result.String(), nil
+}
+
+// githubDelimiter returns a random heredoc delimiter that does not occur in
+// value.
+func githubDelimiter(value string) (string, error) {
+	random := make([]byte, 16)
+	for {
+		if _, err := rand.Read(random); err != nil {
+			return "", fmt.Errorf("generating a delimiter: %w", err)
+		}
+		delimiter := "ghadelimiter_" + hex.EncodeToString(random)
+		if !strings.Contains(value, delimiter) {
+			return delimiter, nil
+		}
+	}
+}
+
+// GitHubMasks returns ::add-mask:: workflow commands that hide the values of
+// the variables whose names match options.SecretPattern, or SecretPattern if
+// it is nil, in the logs of GitHub Actions. Masks apply to single lines, so
+// multiline values are masked line by line. The commands take effect when
+// printed to standard output or standard error in a workflow step.
+func GitHubMasks(variables []Variable, options RenderOptions) string {
+	pattern := options.SecretPattern
+	if pattern == nil {
+		pattern = SecretPattern
+	}
+
+	var result strings.Builder
+	for _, variable := range Merge(variables) {
+		if !pattern.MatchString(variable.Name) {
+			continue
+		}
+		for _, line := range strings.Split(variable.Value, "\n") {
+			line = strings.TrimSuffix(line, "\r")
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			fmt.Fprintf(&result, "::add-mask::%s\n", escapeWorkflowCommand(line))
+		}
+	}
+	return result.String()
+}
+
+// escapeWorkflowCommand escapes the data of a GitHub Actions workflow
+// command.
+func escapeWorkflowCommand(data string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(data)
+}
+
+// renderGitLabDotenv renders a file for artifacts:reports:dotenv in GitLab
+// CI. GitLab reads KEY=value lines without quoting, strips whitespace around
+// values and rejects multiline values and reports larger than 5 KiB, so such
+// input makes it fail.
+func renderGitLabDotenv(variables []Variable, _ RenderOptions) (string, error) {
+	var multiline, padded []string
+	var result strings.Builder
+	for _, variable := range variables {
+		switch {
+		case strings.ContainsAny(variable.Value, "\r\n"):
+			multiline = append(multiline, variable.Name)
+		case strings.TrimSpace(variable.Value) != variable.Value:
+			padded = append(padded, variable.Name)
+		default:
+			fmt.Fprintf(&result, "%s=%s\n", variable.Name, variable.Value)
+		}
+	}
+
+	switch {
+	case len(multiline) > 0:
+		return "", fmt.Errorf("the values of %s contain line breaks, which GitLab dotenv reports cannot represent", strings.Join(multiline, ", "))
+	case len(padded) > 0:
+		return "", fmt.Errorf("the values of %s start or end with whitespace, which GitLab dotenv reports remove", strings.Join(padded, ", "))
+	case result.Len() > gitlabDotenvLimit:
+		return "", fmt.Errorf("the output is %d bytes, more than the %d bytes GitLab accepts for dotenv reports", result.Len(), gitlabDotenvLimit)
+	}
+	return result.String(), nil
+}
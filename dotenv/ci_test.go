@@ -0,0 +1,102 @@
+package dotenv
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var ciVariables = []Variable{
+	{Name: "HOST", Value: "localhost"},
+	{Name: "EMPTY", Value: ""},
+	{Name: "API_TOKEN", Value: "100%secret"},
+	{Name: "PRIVATE_KEY", Value: "-----BEGIN-----\nabc\n-----END-----\n"},
+}
+
+// parseGitHubEnv reads a $GITHUB_ENV file the way the runner does.
+func parseGitHubEnv(t *testing.T, content string) []Variable {
+	t.Helper()
+	var variables []Variable
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		name, delimiter, found := strings.Cut(lines[i], "<<")
+		if !found {
+			t.Fatalf("line %q is not a heredoc", lines[i])
+		}
+		var value []string
+		for i++; i < len(lines) && lines[i] != delimiter; i++ {
+			value = append(value, lines[i])
+		}
+		if i == len(lines) {
+			t.Fatalf("delimiter %q of %s not found", delimiter, name)
+		}
+		variables = append(variables, Variable{Name: name, Value: strings.Join(value, "\n")})
+	}
+	return variables
+}
+
+func TestRenderGitHub(t *testing.T) {
+	output, err := Render(ciVariables, "github")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	variables := parseGitHubEnv(t, output)
+	if len(variables) != len(ciVariables) {
+		t.Fatalf("parsed %d variables, want %d:\n%s", len(variables), len(ciVariables), output)
+	}
+	for i, variable := range variables {
+		if variable.Name != ciVariables[i].Name || variable.Value != ciVariables[i].Value {
+			t.Errorf("parsed %s=%q, want %s=%q", variable.Name, variable.Value, ciVariables[i].Name, ciVariables[i].Value)
+		}
+	}
+
+	again, _ := Render(ciVariables, "github")
+	if again == output {
+		t.Errorf("Render() returned the same delimiters twice")
+	}
+}
+
+func TestGitHubMasks(t *testing.T) {
+	masks := GitHubMasks(ciVariables, RenderOptions{})
+	expected := "::add-mask::100%25secret\n" +
+		"::add-mask::-----BEGIN-----\n" +
+		"::add-mask::abc\n" +
+		"::add-mask::-----END-----\n"
+	if masks != expected {
+		t.Errorf("GitHubMasks() = %q, want %q", masks, expected)
+	}
+
+	masks = GitHubMasks(ciVariables, RenderOptions{SecretPattern: regexp.MustCompile(`^HOST$`)})
+	if masks != "::add-mask::localhost\n" {
+		t.Errorf("GitHubMasks() with a pattern = %q", masks)
+	}
+}
+
+func TestRenderGitLabDotenv(t *testing.T) {
+	output, err := Render(ciVariables[:3], "gitlab-dotenv")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := "HOST=localhost\nEMPTY=\nAPI_TOKEN=100%secret\n"
+	if output != expected {
+		t.Errorf("Render() = %q, want %q", output, expected)
+	}
+
+	tests := []struct {
+		name      string
+		variables []Variable
+		error     string
+	}{
+		{"multiline", ciVariables, "PRIVATE_KEY contain line breaks"},
+		{"whitespace", []Variable{{Name: "A", Value: " a"}, {Name: "B", Value: "b\t"}}, "A, B start or end with whitespace"},
+		{"too large", []Variable{{Name: "A", Value: strings.Repeat("a", 5*1024)}}, "more than the 5120 bytes"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Render(test.variables, "gitlab-dotenv")
+			if err == nil || !strings.Contains(err.Error(), test.error) {
+				t.Errorf("Render() error = %v, want it to contain %q", err, test.error)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package dotenv
+
+import (
+	"sort"
+	"strings"
+)
+
+// ChangeKind classifies a Difference.
+type ChangeKind int
+
+const (
+	// Added is reported for a variable that is only defined afterwards.
+	Added ChangeKind = iota + 1
+	// Removed is reported for a variable that is only defined before.
+	Removed
+	// Changed is reported for a variable that has different values.
+	Changed
+)
+
+// String returns "added", "removed" or "changed".
+func (k ChangeKind) String() string {
+	switch k {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	case Changed:
+		return "changed"
+	default:
+		return "unknown"
+	}
+}
+
+// Difference is a variable whose definition differs between two sets.
+type Difference struct {
+	Name string
+	Kind ChangeKind
+	// Old and New are the definitions before and after. Old is the zero
+	// Variable for Added and New for Removed.
+	Old Variable
+	New Variable
+}
+
+// Compare returns the differences from the variables in before to those in
+// after, ordered by name. When a name is defined several times on one side,
+// the last definition counts.
+func Compare(before, after []Variable) []Difference {
+	oldByName := make(map[string]Variable, len(before))
+	for _, variable := range before {
+		oldByName[variable.Name] = variable
+	}
+	newByName := make(map[string]Variable, len(after))
+	for _, variable := range after {
+		newByName[variable.Name] = variable
+	}
+
+	var differences []Difference
+	for name, oldVariable := range oldByName {
+		newVariable, exists := newByName[name]
+		switch {
+		case !exists:
+			differences = append(differences, Difference{Name: name, Kind: Removed, Old: oldVariable})
+		case oldVariable.Value != newVariable.Value:
+			differences = append(differences, Difference{Name: name, Kind: Changed, Old: oldVariable, New: newVariable})
+		}
+	}
+	for name, newVariable := range newByName {
+		if _, exists := oldByName[name]; !exists {
+			differences = append(differences, Difference{Name: name, Kind: Added, New: newVariable})
+		}
+	}
+
+	sort.Slice(differences, func(i, j int) bool {
+		return differences[i].Name < differences[j].Name
+	})
+	return differences
+}
+
+// EnvironVariables converts environ, in the "NAME=value" form of os.Environ,
+// to variables without a file.
+func EnvironVariables(environ []string) []Variable {
+	variables := make([]Variable, 0, len(environ))
+	for _, entry := range environ {
+		if name, value, found := strings.Cut(entry, "="); found && name != "" {
+			variables = append(variables, Variable{Name: name, Value: value})
+		}
+	}
+	return variables
+}
@@ -0,0 +1,50 @@
+package dotenv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	before := []Variable{
+		{Name: "SAME", Value: "1", File: "a", Line: 1},
+		{Name: "CHANGED", Value: "old", File: "a", Line: 2},
+		{Name: "REMOVED", Value: "x", File: "a", Line: 3},
+		{Name: "REDEFINED", Value: "first", File: "a", Line: 4},
+		{Name: "REDEFINED", Value: "second", File: "a", Line: 5},
+	}
+	after := []Variable{
+		{Name: "ADDED", Value: "y", File: "b", Line: 1},
+		{Name: "CHANGED", Value: "new", File: "b", Line: 2},
+		{Name: "REDEFINED", Value: "second", File: "b", Line: 3},
+		{Name: "SAME", Value: "1", File: "b", Line: 4},
+	}
+
+	expected := []Difference{
+		{Name: "ADDED", Kind: Added, New: Variable{Name: "ADDED", Value: "y", File: "b", Line: 1}},
+		{Name: "CHANGED", Kind: Changed,
+			Old: Variable{Name: "CHANGED", Value: "old", File: "a", Line: 2},
+			New: Variable{Name: "CHANGED", Value: "new", File: "b", Line: 2}},
+		{Name: "REMOVED", Kind: Removed, Old: Variable{Name: "REMOVED", Value: "x", File: "a", Line: 3}},
+	}
+
+	if differences := Compare(before, after); !reflect.DeepEqual(differences, expected) {
+		t.Errorf("Compare() = %v, want %v", differences, expected)
+	}
+	if differences := Compare(after, after); len(differences) != 0 {
+		t.Errorf("Compare() of equal sets = %v, want none", differences)
+	}
+}
+
+func TestEnvironVariables(t *testing.T) {
+	environ := []string{"A=1", "B=x=y", "EMPTY=", "=C:=C:\\", "INVALID"}
+	expected := []Variable{
+		{Name: "A", Value: "1"},
+		{Name: "B", Value: "x=y"},
+		{Name: "EMPTY", Value: ""},
+	}
+
+	if variables := EnvironVariables(environ); !reflect.DeepEqual(variables, expected) {
+		t.Errorf("EnvironVariables() = %v, want %v", variables, expected)
+	}
+}
@@ -0,0 +1,41 @@
+package dotenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderDocker renders an env file for `docker run --env-file`, which takes
+// everything after the first '=' literally and has no quoting. Values with
+// line breaks cannot be represented and make it fail.
+func renderDocker(variables []Variable, _ RenderOptions) (string, error) {
+	var unrepresentable []string
+	var result strings.Builder
+	for _, variable := range variables {
+		if strings.ContainsAny(variable.Value, "\r\n") {
+			unrepresentable = append(unrepresentable, variable.Name)
+			continue
+		}
+		fmt.Fprintf(&result, "%s=%s\n", variable.Name, variable.Value)
+	}
+
+	if len(unrepresentable) > 0 {
+		return "", fmt.Errorf("the values of %s contain line breaks, which docker env files cannot represent", strings.Join(unrepresentable, ", "))
+	}
+	return result.String(), nil
+}
+
+// renderCompose renders an environment block for a Docker Compose service.
+// Compose interpolates $ in values, so it is escaped as $$.
+func renderCompose(variables []Variable, _ RenderOptions) (string, error) {
+	if len(variables) == 0 {
+		return "environment: {}\n", nil
+	}
+
+	var result strings.Builder
+	result.WriteString("environment:\n")
+	for _, variable := range variables {
+		writeYAMLEntry(&result, "  ", variable.Name, strings.ReplaceAll(variable.Value, "$", "$$"))
+	}
+	return result.String(), nil
+}
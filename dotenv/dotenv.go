@@ -0,0 +1,127 @@
+// Package dotenv parses .env files using the same rules as the dotenv
+// command-line tool and loads the result into the process environment.
+package dotenv
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// Parse reads dotenv syntax from r and returns the variables in the order
+// they were defined.
+func Parse(r io.Reader) ([]Variable, error) {
+	return NewParser().ParseReader(r)
+}
+
+// ParseFile reads and parses the dotenv file at filename.
+func ParseFile(filename string) ([]Variable, error) {
+	return NewParser().ParseFile(filename)
+}
+
+// ParseFiles parses the given files in order and returns the merged result,
+// as described by Merge. Values may reference variables from files parsed
+// before them.
+func ParseFiles(filenames ...string) ([]Variable, error) {
+	parser := NewParser()
+	var variables []Variable
+	for _, filename := range filenames {
+		var err error
+		variables, err = parser.ParseFile(filename)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return Merge(variables), nil
+}
+
+// Merge removes repeated definitions from variables. Each variable keeps the
+// position of its first definition and takes the value, file and line of its
+// last one.
+func Merge(variables []Variable) []Variable {
+	index := make(map[string]int, len(variables))
+	merged := make([]Variable, 0, len(variables))
+	for _, variable := range variables {
+		if i, exists := index[variable.Name]; exists {
+			merged[i] = variable
+			continue
+		}
+		index[variable.Name] = len(merged)
+		merged = append(merged, variable)
+	}
+	return merged
+}
+
+// EnvFiles returns the conventional layering of dotenv files for the
+// environment env, from lowest to highest precedence: .env, .env.local,
+// .env.<env> and .env.<env>.local. Without an environment only the first two
+// are returned.
+func EnvFiles(env string) []string {
+	files := []string{".env", ".env.local"}
+	if env != "" {
+		files = append(files, ".env."+env, ".env."+env+".local")
+	}
+	return files
+}
+
+// Load reads the given files (".env" if none are given) and sets every
+// variable that is not already present in the environment.
+func Load(filenames ...string) error {
+	return load(false, filenames)
+}
+
+// Overload reads the given files (".env" if none are given) and sets every
+// variable, replacing values that are already present in the environment.
+func Overload(filenames ...string) error {
+	return load(true, filenames)
+}
+
+func load(override bool, filenames []string) error {
+	if len(filenames) == 0 {
+		filenames = []string{".env"}
+	}
+
+	variables, err := ParseFiles(filenames...)
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range variables {
+		if _, exists := os.LookupEnv(variable.Name); exists && !override {
+			continue
+		}
+		if err := os.Setenv(variable.Name, variable.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MergeEnviron returns environ (in the "NAME=value" form of os.Environ) with
+// variables added to it. Variables that are already present in environ are
+// only replaced if override is true.
+func MergeEnviron(environ []string, variables []Variable, override bool) []string {
+	index := make(map[string]int, len(environ))
+	merged := make([]string, 0, len(environ)+len(variables))
+	for _, entry := range environ {
+		if name, _, found := strings.Cut(entry, "="); found {
+			index[name] = len(merged)
+		}
+		merged = append(merged, entry)
+	}
+
+	for _, variable := range variables {
+		entry := variable.Name + "=" + variable.Value
+		i, exists := index[variable.Name]
+		switch {
+		case !exists:
+			index[variable.Name] = len(merged)
+			merged = append(merged, entry)
+		case override || i >= len(environ):
+			// Variables defined by the file itself always replace their
+			// earlier definitions
+			merged[i] = entry
+		}
+	}
+	return merged
+}
@@ -0,0 +1,137 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseReader(t *testing.T) {
+	input := "# comment\nFOO=bar\nexport BAZ=\"${FOO} baz\"\n"
+
+	variables, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	expected := []Variable{
+		{Name: "FOO", Value: "bar", Line: 2},
+		{Name: "BAZ", Value: "bar baz", Line: 3},
+	}
+
+	if !reflect.DeepEqual(variables, expected) {
+		t.Errorf("Parse() = %v, want %v", variables, expected)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, ".env")
+	content := "DOTENV_LOAD_NEW=new\nDOTENV_LOAD_EXISTING=from_file\nDOTENV_LOAD_NEW=newer\n"
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	t.Setenv("DOTENV_LOAD_EXISTING", "from_env")
+	t.Setenv("DOTENV_LOAD_NEW", "")
+	os.Unsetenv("DOTENV_LOAD_NEW")
+
+	if err := Load(testFile); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if value := os.Getenv("DOTENV_LOAD_NEW"); value != "newer" {
+		t.Errorf("DOTENV_LOAD_NEW = %q, want %q", value, "newer")
+	}
+	if value := os.Getenv("DOTENV_LOAD_EXISTING"); value != "from_env" {
+		t.Errorf("DOTENV_LOAD_EXISTING = %q, want %q", value, "from_env")
+	}
+}
+
+func TestOverload(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, ".env")
+	if err := os.WriteFile(testFile, []byte("DOTENV_OVERLOAD=from_file\n"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	t.Setenv("DOTENV_OVERLOAD", "from_env")
+
+	if err := Overload(testFile); err != nil {
+		t.Fatalf("Overload() error = %v", err)
+	}
+
+	if value := os.Getenv("DOTENV_OVERLOAD"); value != "from_file" {
+		t.Errorf("DOTENV_OVERLOAD = %q, want %q", value, "from_file")
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if err := Load(filepath.Join(t.TempDir(), "missing.env")); err == nil {
+		t.Error("Load() should have returned an error for a missing file")
+	}
+}
+
+func TestMergeEnviron(t *testing.T) {
+	environ := []string{"PATH=/bin", "HOME=/root"}
+	variables := []Variable{
+		{Name: "HOME", Value: "/home/app"},
+		{Name: "PORT", Value: "3000"},
+		{Name: "PORT", Value: "4000"},
+	}
+
+	tests := []struct {
+		name     string
+		override bool
+		expected []string
+	}{
+		{"keep existing", false, []string{"PATH=/bin", "HOME=/root", "PORT=4000"}},
+		{"override existing", true, []string{"PATH=/bin", "HOME=/home/app", "PORT=4000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := MergeEnviron(environ, variables, tt.override)
+			if !reflect.DeepEqual(merged, tt.expected) {
+				t.Errorf("MergeEnviron() = %v, want %v", merged, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := map[string]string{
+		".env":             "APP=base\nURL=http://${DOTENV_HOST}/\nDOTENV_HOST=localhost\n",
+		".env.local":       "DOTENV_HOST=local.test\n",
+		".env.development": "APP=dev\nFULL=${APP}@${DOTENV_HOST}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	found := SearchFiles([]string{tmpDir}, EnvFiles("development"), false)
+	if len(found) != 3 {
+		t.Fatalf("SearchFiles() = %v, want 3 files", found)
+	}
+
+	variables, err := ParseFiles(found...)
+	if err != nil {
+		t.Fatalf("ParseFiles() error = %v", err)
+	}
+
+	expected := []Variable{
+		{Name: "APP", Value: "dev", File: found[2], Line: 1},
+		{Name: "URL", Value: "http:///", File: found[0], Line: 2},
+		{Name: "DOTENV_HOST", Value: "local.test", File: found[1], Line: 1},
+		{Name: "FULL", Value: "dev@local.test", File: found[2], Line: 2},
+	}
+
+	if !reflect.DeepEqual(variables, expected) {
+		t.Errorf("ParseFiles() = %v, want %v", variables, expected)
+	}
+}
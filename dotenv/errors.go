@@ -0,0 +1,118 @@
+package dotenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorKind classifies a ParseError.
+type ErrorKind int
+
+const (
+	// InvalidName is reported for a key that is not a valid variable name.
+	InvalidName ErrorKind = iota + 1
+	// UnterminatedQuote is reported for a single or double quoted value
+	// without a closing quote.
+	UnterminatedQuote
+	// UnterminatedMultiline is reported for a triple-quoted value without a
+	// closing delimiter.
+	UnterminatedMultiline
+	// RequiredVariable is reported when a ${VAR:?message} or ${VAR?message}
+	// reference finds its variable unset (or empty).
+	RequiredVariable
+)
+
+// String returns a short description of the kind.
+func (k ErrorKind) String() string {
+	switch k {
+	case InvalidName:
+		return "invalid variable name"
+	case UnterminatedQuote:
+		return "unterminated quoted string"
+	case UnterminatedMultiline:
+		return "unterminated multiline string"
+	case RequiredVariable:
+		return "required variable"
+	default:
+		return "parse error"
+	}
+}
+
+// ParseError describes a syntax or interpolation error in dotenv input.
+type ParseError struct {
+	// File is the name of the file, empty if the input was not a file.
+	File string
+	// Line and Column locate the error, both counted from 1. Column counts
+	// bytes.
+	Line   int
+	Column int
+	Kind   ErrorKind
+	// Message describes the error in detail.
+	Message string
+	// Snippet is the complete source line the error was found on.
+	Snippet string
+}
+
+// Error formats the error as file:line:column: message.
+func (e *ParseError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// ErrorList is returned by a recovering Parser and holds every error found,
+// in input order.
+type ErrorList []*ParseError
+
+// Error returns the first error and the number of further errors.
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// Unwrap returns the errors of the list, so that errors.As finds the first
+// ParseError.
+func (l ErrorList) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, err := range l {
+		errs[i] = err
+	}
+	return errs
+}
+
+// position is a location in the input being parsed, with line and column
+// both counted from 1.
+type position struct {
+	line   int
+	column int
+}
+
+// advance returns the position just after text, when text starts at pos.
+func (pos position) advance(text string) position {
+	if i := strings.LastIndexByte(text, '\n'); i != -1 {
+		return position{line: pos.line + strings.Count(text, "\n"), column: len(text) - i}
+	}
+	return position{line: pos.line, column: pos.column + len(text)}
+}
+
+// errorAt returns a ParseError of the given kind at pos in the current input.
+func (p *Parser) errorAt(pos position, kind ErrorKind, message string) *ParseError {
+	snippet := ""
+	if index := p.lineOffset + pos.line - 1; index >= 0 && index < len(p.lines) {
+		snippet = p.lines[index]
+	}
+	return &ParseError{
+		File:    p.filename,
+		Line:    pos.line,
+		Column:  pos.column,
+		Kind:    kind,
+		Message: message,
+		Snippet: snippet,
+	}
+}
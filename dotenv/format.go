@@ -0,0 +1,238 @@
+package dotenv
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ExportMode selects how Format treats "export" prefixes.
+type ExportMode int
+
+const (
+	// ExportKeep keeps the prefix on the entries that have it.
+	ExportKeep ExportMode = iota
+	// ExportAlways adds the prefix to every entry.
+	ExportAlways
+	// ExportNever removes the prefix from every entry.
+	ExportNever
+)
+
+// FormatOptions configures Format.
+type FormatOptions struct {
+	// Sort orders the entries by key. Comments directly above an entry move
+	// with it.
+	Sort bool
+	// Export selects how "export" prefixes are written.
+	Export ExportMode
+}
+
+// Format returns the canonical form of doc: no indentation, no spaces
+// around '=', minimal quoting, comments and blank lines trimmed, at most one
+// blank line in a row and a final newline. References are kept as written,
+// so values containing them keep their quoting.
+//
+// Format fails if doc cannot be parsed, or if the formatted document would
+// load different values, which can happen when sorting moves a reference
+// before the definition it refers to.
+func Format(doc *Document, options FormatOptions) (*Document, error) {
+	before, err := formatVariables(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	newline := doc.newline()
+	formatted := &Document{}
+	for _, node := range doc.Nodes {
+		formatted.Nodes = append(formatted.Nodes, formatNode(node, options.Export, newline))
+	}
+	if options.Sort {
+		formatted.Nodes = sortNodes(formatted.Nodes, newline)
+	}
+	formatted.Nodes = squeezeBlanks(formatted.Nodes)
+
+	after, err := formatVariables(formatted)
+	if err != nil {
+		return nil, err
+	}
+	if !sameValues(before, after) {
+		return nil, errors.New("formatting would change the loaded values")
+	}
+	return formatted, nil
+}
+
+// formatNode returns the canonical form of a single node.
+func formatNode(node Node, export ExportMode, newline string) Node {
+	switch node := node.(type) {
+	case *Blank:
+		return &Blank{Raw: newline}
+	case *Comment:
+		return &Comment{Raw: strings.TrimSpace(node.Raw) + newline}
+	case *Entry:
+		entry := &Entry{Key: node.Key, Separator: "=", Quote: node.Quote, RawValue: node.RawValue, Newline: newline}
+		switch {
+		case export == ExportAlways, export == ExportKeep && node.Export != "":
+			entry.Export = "export "
+		}
+		suffix := strings.TrimSpace(node.Suffix)
+		if suffix != "" && suffix[0] != '#' {
+			// Text after a closing quote is ignored by the parser but would
+			// become part of the value if the quotes were changed
+			entry.Quote, entry.RawValue, entry.Suffix = node.Quote, node.RawValue, node.Suffix
+			return entry
+		}
+		if value, ok := literalValue(node); ok {
+			entry.Quote = MinimalQuote(value)
+			entry.RawValue, _ = EncodeValue(value, entry.Quote)
+		}
+		if suffix != "" {
+			entry.Suffix = " " + suffix
+		}
+		return entry
+	default:
+		// Lines that are not understood are kept as they are
+		return &Unparsed{Raw: strings.TrimRight(node.Text(), "\r\n") + newline}
+	}
+}
+
+// literalValue returns the value of entry if it does not depend on other
+// variables, and false if it contains references.
+func literalValue(entry *Entry) (string, bool) {
+	if entry.Quote != SingleQuoted && entry.Quote != TripleSingleQuoted && hasReference(entry.RawValue) {
+		return "", false
+	}
+	delimiter := entry.Quote.Delimiter()
+	variables, err := Parse(strings.NewReader("KEY=" + delimiter + entry.RawValue + delimiter))
+	if err != nil || len(variables) != 1 {
+		return "", false
+	}
+	return variables[0].Value, true
+}
+
+// hasReference reports whether raw, a value subject to interpolation,
+// contains a $NAME or ${...} reference.
+func hasReference(raw string) bool {
+	for i := 0; i < len(raw)-1; i++ {
+		switch raw[i] {
+		case '\\':
+			i++
+		case '$':
+			next := raw[i+1]
+			if next == '{' || isNameChar(next, true) {
+				return true
+			}
+			if next == '$' {
+				i++
+			}
+		}
+	}
+	return false
+}
+
+// sortNodes orders the entries in nodes by key. Leading comments separated
+// from the first entry by a blank line stay at the top, comments directly
+// above an entry move with it, and comments after the last entry stay at the
+// end. Blank lines between entries are dropped.
+func sortNodes(nodes []Node, newline string) []Node {
+	type unit struct {
+		key   string
+		nodes []Node
+	}
+
+	var header, pending []Node
+	var units []unit
+	seenEntry := false
+	for _, node := range nodes {
+		switch node := node.(type) {
+		case *Blank:
+			if !seenEntry {
+				header = append(header, pending...)
+				header = append(header, node)
+				pending = nil
+			}
+		case *Entry:
+			units = append(units, unit{key: node.Key, nodes: append(pending, node)})
+			pending = nil
+			seenEntry = true
+		default:
+			pending = append(pending, node)
+		}
+	}
+
+	sort.SliceStable(units, func(i, j int) bool {
+		return units[i].key < units[j].key
+	})
+
+	sorted := header
+	for _, u := range units {
+		sorted = append(sorted, u.nodes...)
+	}
+	if len(pending) > 0 {
+		sorted = append(sorted, &Blank{Raw: newline})
+		sorted = append(sorted, pending...)
+	}
+	return sorted
+}
+
+// squeezeBlanks removes blank lines at the start and end of nodes and
+// collapses consecutive blank lines into one.
+func squeezeBlanks(nodes []Node) []Node {
+	var result []Node
+	for _, node := range nodes {
+		if _, blank := node.(*Blank); blank {
+			if len(result) == 0 {
+				continue
+			}
+			if _, previousBlank := result[len(result)-1].(*Blank); previousBlank {
+				continue
+			}
+		}
+		result = append(result, node)
+	}
+	for len(result) > 0 {
+		if _, blank := result[len(result)-1].(*Blank); !blank {
+			break
+		}
+		result = result[:len(result)-1]
+	}
+	return result
+}
+
+// formatVariables parses doc and fails on syntax errors. Required variables
+// that are missing from the environment are not a problem for formatting.
+func formatVariables(doc *Document) ([]Variable, error) {
+	parser := NewParser()
+	parser.SetRecover(true)
+	variables, err := parser.ParseReader(strings.NewReader(doc.String()))
+
+	var errs ErrorList
+	if errors.As(err, &errs) {
+		var syntaxErrs ErrorList
+		for _, parseErr := range errs {
+			if parseErr.Kind != RequiredVariable {
+				syntaxErrs = append(syntaxErrs, parseErr)
+			}
+		}
+		if len(syntaxErrs) > 0 {
+			return nil, syntaxErrs
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	return variables, nil
+}
+
+// sameValues reports whether both lists define the same final value for
+// every name.
+func sameValues(a, b []Variable) bool {
+	return reflect.DeepEqual(finalValues(a), finalValues(b))
+}
+
+func finalValues(variables []Variable) map[string]string {
+	values := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		values[variable.Name] = variable.Value
+	}
+	return values
+}
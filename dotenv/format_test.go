@@ -0,0 +1,144 @@
+package dotenv
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		options  FormatOptions
+		expected string
+	}{
+		{
+			name:     "spacing and final newline",
+			input:    "  export   A = 1   # one\n\tB\t=\t2",
+			expected: "export A=1 # one\nB=2\n",
+		},
+		{
+			name:     "minimal quoting",
+			input:    "A=\"plain\"\nB=\"two words\"\nC='it\\'s'\nD=\"\"\"\nl1\nl2\n\"\"\"\nE=\"\\$HOME\"\n",
+			expected: "A=plain\nB='two words'\nC='it\\'s'\nD='''\nl1\nl2\n'''\nE='$HOME'\n",
+		},
+		{
+			name:     "text after a closing quote",
+			input:    "A = 'x' 'y'\n",
+			expected: "A='x' 'y'\n",
+		},
+		{
+			name:     "references keep their quoting",
+			input:    "A=1\nB=\"${A} x\"\nC=$A\n",
+			expected: "A=1\nB=\"${A} x\"\nC=$A\n",
+		},
+		{
+			name:     "blank lines and comments",
+			input:    "\n\n  # comment  \nA=1\n\n\n\nB=2\n\n",
+			expected: "# comment\nA=1\n\nB=2\n",
+		},
+		{
+			name:     "crlf",
+			input:    "A = 1\r\n\r\nB=\"x\"",
+			expected: "A=1\r\n\r\nB=x\r\n",
+		},
+		{
+			name:     "export always",
+			input:    "A=1\nexport B=2\n",
+			options:  FormatOptions{Export: ExportAlways},
+			expected: "export A=1\nexport B=2\n",
+		},
+		{
+			name:     "export never",
+			input:    "A=1\nexport B=2\n",
+			options:  FormatOptions{Export: ExportNever},
+			expected: "A=1\nB=2\n",
+		},
+		{
+			name: "sort",
+			input: "# header\n\n" +
+				"# about C\nC=3\n\n" +
+				"A=1\n" +
+				"# detached\n\n" +
+				"B=2\n" +
+				"# footer\n",
+			options: FormatOptions{Sort: true},
+			expected: "# header\n\n" +
+				"A=1\n" +
+				"# detached\nB=2\n" +
+				"# about C\nC=3\n\n" +
+				"# footer\n",
+		},
+		{
+			name:     "missing required variable",
+			input:    "A = ${DOTENV_FORMAT_UNSET:?}\n",
+			expected: "A=${DOTENV_FORMAT_UNSET:?}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseDocument(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseDocument() error = %v", err)
+			}
+			formatted, err := Format(doc, tt.options)
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if output := formatted.String(); output != tt.expected {
+				t.Errorf("Format() = %q, want %q", output, tt.expected)
+			}
+
+			again, err := Format(formatted, tt.options)
+			if err != nil {
+				t.Fatalf("Format() of formatted output error = %v", err)
+			}
+			if output := again.String(); output != tt.expected {
+				t.Errorf("Format() is not idempotent: %q", output)
+			}
+		})
+	}
+}
+
+func TestFormatTestEnv(t *testing.T) {
+	input, err := os.ReadFile("../test.env")
+	if err != nil {
+		t.Fatalf("Failed to read test.env: %v", err)
+	}
+	doc, err := ParseDocument(strings.NewReader(string(input)))
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+	formatted, err := Format(doc, FormatOptions{})
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	again, err := Format(formatted, FormatOptions{})
+	if err != nil {
+		t.Fatalf("Format() of formatted output error = %v", err)
+	}
+	if again.String() != formatted.String() {
+		t.Errorf("Format() is not idempotent")
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	inputs := map[string]string{
+		"syntax error":             "A=\"open\n",
+		"sort changes a reference": "B=${A}\nA=1\n",
+	}
+
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			doc, err := ParseDocument(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("ParseDocument() error = %v", err)
+			}
+			if _, err := Format(doc, FormatOptions{Sort: true}); err == nil {
+				t.Errorf("Format() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package dotenv
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// interpolateReference handles the reference starting with the $ at the
+// beginning of value, writes its expansion to result and returns the number
+// of bytes it spans. Supported are $NAME, ${NAME}, the POSIX operators
+// ${NAME:-default}, ${NAME-default}, ${NAME:+alternate}, ${NAME+alternate},
+// ${NAME:?message} and ${NAME?message}, and $$ for a literal dollar sign. A $
+// that starts none of these is kept as is. pos is where value starts in the
+// input.
+func (p *Parser) interpolateReference(value string, result *strings.Builder, pos position) (int, error) {
+	if len(value) < 2 {
+		result.WriteByte('$')
+		return 1, nil
+	}
+
+	switch {
+	case value[1] == '$':
+		result.WriteByte('$')
+		return 2, nil
+	case value[1] == '{':
+		// Find the matching closing brace
+		closeIndex := matchingBrace(value[2:])
+		if closeIndex == -1 {
+			break
+		}
+		expanded, err := p.expandParameter(value[2:2+closeIndex], pos)
+		if err != nil {
+			return 0, err
+		}
+		result.WriteString(expanded)
+		return 2 + closeIndex + 1, nil // Skip past the }
+	case isNameChar(value[1], true):
+		nameEnd := 2
+		for nameEnd < len(value) && isNameChar(value[nameEnd], false) {
+			nameEnd++
+		}
+		result.WriteString(p.lookupReference(value[1:nameEnd], pos))
+		return nameEnd, nil
+	}
+
+	result.WriteByte('$')
+	return 1, nil
+}
+
+// matchingBrace returns the index of the } closing an expression that starts
+// at the beginning of s, skipping over nested braces and escaped characters,
+// or -1 if there is none.
+func matchingBrace(s string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// expandParameter evaluates the expression between ${ and }. pos is where the
+// reference starts in the input.
+func (p *Parser) expandParameter(expression string, pos position) (string, error) {
+	nameEnd := 0
+	for nameEnd < len(expression) && isNameChar(expression[nameEnd], nameEnd == 0) {
+		nameEnd++
+	}
+	name := expression[:nameEnd]
+	operator, word := splitOperator(expression[nameEnd:])
+	if name == "" || operator == "" {
+		// Not an operator expression: look up the whole text as a name
+		return p.lookupReference(expression, pos), nil
+	}
+
+	value, set := p.lookupVariable(name)
+	empty := value == ""
+	wordPos := pos.advance("${" + name + operator)
+
+	switch operator {
+	case ":-":
+		if !set || empty {
+			return p.processEscapesAndInterpolation(word, wordPos)
+		}
+	case "-":
+		if !set {
+			return p.processEscapesAndInterpolation(word, wordPos)
+		}
+	case ":+":
+		if set && !empty {
+			return p.processEscapesAndInterpolation(word, wordPos)
+		}
+		return "", nil
+	case "+":
+		if set {
+			return p.processEscapesAndInterpolation(word, wordPos)
+		}
+		return "", nil
+	case ":?", "?":
+		if !set || (operator == ":?" && empty) {
+			message, err := p.processEscapesAndInterpolation(word, wordPos)
+			if err != nil {
+				return "", err
+			}
+			if message == "" {
+				if operator == ":?" {
+					message = "required variable is not set or empty"
+				} else {
+					message = "required variable is not set"
+				}
+			}
+			return "", p.errorAt(pos, RequiredVariable, fmt.Sprintf("%s: %s", name, message))
+		}
+	}
+	return value, nil
+}
+
+// splitOperator splits the text following a name inside ${} into the
+// operator and its word. It returns an empty operator if rest does not start
+// with one.
+func splitOperator(rest string) (operator, word string) {
+	for _, op := range []string{":-", ":+", ":?", "-", "+", "?"} {
+		if strings.HasPrefix(rest, op) {
+			return op, rest[len(op):]
+		}
+	}
+	return "", ""
+}
+
+// lookupVariable returns the latest definition of name from the variables
+// parsed so far, falling back to the defaults and then the process
+// environment.
+func (p *Parser) lookupVariable(name string) (string, bool) {
+	for i := len(p.variables) - 1; i >= 0; i-- {
+		if p.variables[i].Name == name {
+			return p.variables[i].Value, true
+		}
+	}
+	for _, variable := range p.defaults {
+		if variable.Name == name {
+			return variable.Value, true
+		}
+	}
+	return os.LookupEnv(name)
+}
+
+// lookupReference returns the value of a plain reference to name found at
+// pos. An undefined variable expands to an empty string.
+func (p *Parser) lookupReference(name string, pos position) string {
+	value, found := p.lookupVariable(name)
+	if !found && p.undefined != nil {
+		p.undefined(name, pos)
+	}
+	return value
+}
+
+// isNameChar reports whether c may appear in a variable name, at the first
+// position if first is set.
+func isNameChar(c byte, first bool) bool {
+	switch {
+	case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		return true
+	case c >= '0' && c <= '9':
+		return !first
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package dotenv
+
+import (
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// renderConfigMap renders a ConfigMap manifest holding all variables.
+func renderConfigMap(variables []Variable, options RenderOptions) (string, error) {
+	if options.Name == "" {
+		return "", errors.New("a name is required for Kubernetes manifests")
+	}
+	return kubernetesManifest("ConfigMap", variables, options), nil
+}
+
+// renderSecret renders an Opaque Secret manifest holding all variables.
+func renderSecret(variables []Variable, options RenderOptions) (string, error) {
+	if options.Name == "" {
+		return "", errors.New("a name is required for Kubernetes manifests")
+	}
+	return kubernetesManifest("Secret", variables, options), nil
+}
+
+// renderKubernetes renders a ConfigMap with the variables that do not match
+// the secret pattern and a Secret with those that do, both with the same
+// name.
+func renderKubernetes(variables []Variable, options RenderOptions) (string, error) {
+	if options.Name == "" {
+		return "", errors.New("a name is required for Kubernetes manifests")
+	}
+	pattern := options.SecretPattern
+	if pattern == nil {
+		pattern = SecretPattern
+	}
+
+	var config, secrets []Variable
+	for _, variable := range variables {
+		if pattern.MatchString(variable.Name) {
+			secrets = append(secrets, variable)
+		} else {
+			config = append(config, variable)
+		}
+	}
+	return kubernetesManifest("ConfigMap", config, options) + "---\n" + kubernetesManifest("Secret", secrets, options), nil
+}
+
+// kubernetesManifest renders a ConfigMap or Secret manifest.
+func kubernetesManifest(kind string, variables []Variable, options RenderOptions) string {
+	var result strings.Builder
+	result.WriteString("apiVersion: v1\n")
+	result.WriteString("kind: " + kind + "\n")
+	result.WriteString("metadata:\n")
+	writeYAMLEntry(&result, "  ", "name", options.Name)
+	if options.Namespace != "" {
+		writeYAMLEntry(&result, "  ", "namespace", options.Namespace)
+	}
+	if len(options.Labels) > 0 {
+		result.WriteString("  labels:\n")
+		keys := make([]string, 0, len(options.Labels))
+		for key := range options.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			writeYAMLEntry(&result, "    ", key, options.Labels[key])
+		}
+	}
+
+	field := "data"
+	if kind == "Secret" {
+		result.WriteString("type: Opaque\n")
+		if options.StringData {
+			field = "stringData"
+		}
+	}
+	if len(variables) == 0 {
+		result.WriteString(field + ": {}\n")
+		return result.String()
+	}
+	result.WriteString(field + ":\n")
+	for _, variable := range variables {
+		value := variable.Value
+		if kind == "Secret" && !options.StringData {
+			value = base64.StdEncoding.EncodeToString([]byte(value))
+		}
+		writeYAMLEntry(&result, "  ", variable.Name, value)
+	}
+	return result.String()
+}
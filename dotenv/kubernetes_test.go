@@ -0,0 +1,136 @@
+package dotenv
+
+import (
+	"regexp"
+	"testing"
+)
+
+var kubernetesVariables = []Variable{
+	{Name: "HOST", Value: "localhost"},
+	{Name: "PORT", Value: "3000"},
+	{Name: "API_TOKEN", Value: "s3cr3t"},
+	{Name: "CERT", Value: "l1\nl2\n"},
+}
+
+func TestRenderKubernetes(t *testing.T) {
+	options := RenderOptions{
+		Name:      "app",
+		Namespace: "prod",
+		Labels:    map[string]string{"tier": "backend", "app": "web"},
+	}
+
+	output, err := RenderWithOptions(kubernetesVariables, "k8s", options)
+	if err != nil {
+		t.Fatalf("RenderWithOptions() error = %v", err)
+	}
+	expected := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+  namespace: prod
+  labels:
+    app: web
+    tier: backend
+data:
+  HOST: localhost
+  PORT: "3000"
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+  namespace: prod
+  labels:
+    app: web
+    tier: backend
+type: Opaque
+data:
+  API_TOKEN: czNjcjN0
+  CERT: bDEKbDIK
+`
+	if output != expected {
+		t.Errorf("RenderWithOptions() =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestRenderSecretStringData(t *testing.T) {
+	options := RenderOptions{Name: "app", StringData: true}
+
+	output, err := RenderWithOptions(kubernetesVariables[2:], "secret", options)
+	if err != nil {
+		t.Fatalf("RenderWithOptions() error = %v", err)
+	}
+	expected := `apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+type: Opaque
+stringData:
+  API_TOKEN: s3cr3t
+  CERT: |
+    l1
+    l2
+`
+	if output != expected {
+		t.Errorf("RenderWithOptions() =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestRenderKubernetesOptions(t *testing.T) {
+	options := RenderOptions{Name: "app", SecretPattern: regexp.MustCompile(`^HOST$`)}
+
+	output, err := RenderWithOptions(kubernetesVariables[:1], "k8s", options)
+	if err != nil {
+		t.Fatalf("RenderWithOptions() error = %v", err)
+	}
+	expected := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+data: {}
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+type: Opaque
+data:
+  HOST: bG9jYWxob3N0
+`
+	if output != expected {
+		t.Errorf("RenderWithOptions() =\n%s\nwant\n%s", output, expected)
+	}
+
+	for _, format := range []string{"configmap", "secret", "k8s"} {
+		if _, err := RenderWithOptions(kubernetesVariables, format, RenderOptions{}); err == nil {
+			t.Errorf("RenderWithOptions(%q) without a name succeeded, want an error", format)
+		}
+	}
+}
+
+func TestRenderKubernetesWorkingDirectory(t *testing.T) {
+	variables := []Variable{{Name: "PWD", Value: "/app"}, {Name: "DB_PWD", Value: "s3cr3t"}}
+
+	output, err := RenderWithOptions(variables, "k8s", RenderOptions{Name: "app"})
+	if err != nil {
+		t.Fatalf("RenderWithOptions() error = %v", err)
+	}
+	expected := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app
+data:
+  PWD: /app
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: app
+type: Opaque
+data:
+  DB_PWD: czNjcjN0
+`
+	if output != expected {
+		t.Errorf("RenderWithOptions() =\n%s\nwant\n%s", output, expected)
+	}
+}
@@ -0,0 +1,305 @@
+package dotenv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Names of the rules checked by Lint.
+const (
+	// RuleSyntax reports errors that make the parser reject a line.
+	RuleSyntax = "syntax"
+	// RuleDuplicateKey reports variables that are defined more than once.
+	RuleDuplicateKey = "duplicate-key"
+	// RuleKeyCase reports names that are not UPPER_SNAKE_CASE.
+	RuleKeyCase = "key-case"
+	// RuleUnquotedSpace reports unquoted values that contain whitespace.
+	RuleUnquotedSpace = "unquoted-space"
+	// RuleTrailingWhitespace reports lines ending in whitespace.
+	RuleTrailingWhitespace = "trailing-whitespace"
+	// RuleUndefinedReference reports $VAR and ${VAR} references to variables
+	// that are neither defined earlier in the file nor in the environment.
+	RuleUndefinedReference = "undefined-reference"
+	// RuleMissingEquals reports lines without '=', which the parser ignores.
+	RuleMissingEquals = "missing-equals"
+	// RuleExportTypo reports misspelled export prefixes such as "Export" or
+	// "exprot".
+	RuleExportTypo = "export-typo"
+	// RuleFinalNewline reports files that do not end with a newline.
+	RuleFinalNewline = "final-newline"
+)
+
+// LintRules lists the names of all rules.
+var LintRules = []string{
+	RuleSyntax,
+	RuleDuplicateKey,
+	RuleKeyCase,
+	RuleUnquotedSpace,
+	RuleTrailingWhitespace,
+	RuleUndefinedReference,
+	RuleMissingEquals,
+	RuleExportTypo,
+	RuleFinalNewline,
+}
+
+// RuleSet selects rules by name. Only rules mapped to true are checked.
+type RuleSet map[string]bool
+
+// AllRules returns a RuleSet with every rule enabled.
+func AllRules() RuleSet {
+	rules := make(RuleSet, len(LintRules))
+	for _, rule := range LintRules {
+		rules[rule] = true
+	}
+	return rules
+}
+
+// Diagnostic is a problem found by Lint.
+type Diagnostic struct {
+	File string
+	// Line and Column locate the problem, both counted from 1. Column counts
+	// bytes.
+	Line    int
+	Column  int
+	Rule    string
+	Message string
+}
+
+// String formats the diagnostic as file:line:column: message (rule).
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s (%s)", d.File, d.Line, d.Column, d.Message, d.Rule)
+}
+
+var (
+	upperSnakeCaseRegex = regexp.MustCompile(`^[A-Z_][A-Z0-9_]*$`)
+	prefixedKeyRegex    = regexp.MustCompile(`^(\S+)\s+[a-zA-Z_][a-zA-Z0-9_]*\s*=`)
+)
+
+type linter struct {
+	filename    string
+	rules       RuleSet
+	lines       []string
+	diagnostics []Diagnostic
+}
+
+// Lint checks content, read from filename, against the given rules and
+// returns the problems found ordered by position.
+func Lint(filename string, content []byte, rules RuleSet) []Diagnostic {
+	l := &linter{
+		filename: filename,
+		rules:    rules,
+		lines:    splitLines(string(content)),
+	}
+
+	superseded := l.checkLines()
+	l.checkDefinitions(content, superseded)
+
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		last := len(l.lines)
+		l.report(RuleFinalNewline, last, len(l.lines[last-1])+1, "file does not end with a newline")
+	}
+
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		a, b := l.diagnostics[i], l.diagnostics[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return l.diagnostics
+}
+
+func (l *linter) report(rule string, line, column int, format string, args ...any) {
+	if !l.rules[rule] {
+		return
+	}
+	l.diagnostics = append(l.diagnostics, Diagnostic{
+		File:    l.filename,
+		Line:    line,
+		Column:  column,
+		Rule:    rule,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// checkLines applies the rules that only need the raw text of each line.
+// It returns the lines on which a more specific problem explains the syntax
+// error the parser reports for them.
+func (l *linter) checkLines() map[int]bool {
+	superseded := make(map[int]bool)
+
+	for i := 0; i < len(l.lines); i++ {
+		line := l.lines[i]
+		number := i + 1
+
+		if end := strings.TrimRightFunc(line, unicode.IsSpace); end != line && end != "" {
+			l.report(RuleTrailingWhitespace, number, len(end)+1, "trailing whitespace")
+		}
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed[0] == '#' {
+			continue
+		}
+		column := len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace)) + 1
+
+		if match := prefixedKeyRegex.FindStringSubmatch(trimmed); match != nil && isExportTypo(match[1]) {
+			l.report(RuleExportTypo, number, column, "%q should be \"export\"", match[1])
+			superseded[number] = true
+		}
+
+		eqIndex := strings.IndexByte(trimmed, '=')
+		if eqIndex == -1 {
+			l.report(RuleMissingEquals, number, column, "line has no '=' and is ignored")
+			continue
+		}
+
+		valuePart := strings.TrimLeftFunc(trimmed[eqIndex+1:], unicode.IsSpace)
+		valueColumn := column + len(trimmed) - len(valuePart)
+
+		if strings.HasPrefix(valuePart, `"""`) || strings.HasPrefix(valuePart, `'''`) {
+			// Skip the body of multiline values, which may contain anything
+			delimiter := valuePart[:3]
+			rest := valuePart[3:]
+			if strings.TrimSpace(rest) == "" || !strings.HasSuffix(rest, delimiter) {
+				for i+1 < len(l.lines) && !strings.HasSuffix(l.lines[i+1], delimiter) {
+					i++
+				}
+				i++
+			}
+			continue
+		}
+
+		if valuePart != "" && valuePart[0] != '"' && valuePart[0] != '\'' {
+			value, _, _ := strings.Cut(valuePart, "#")
+			value = withoutExpansions(strings.TrimRightFunc(value, unicode.IsSpace))
+			if strings.ContainsAny(value, " \t") {
+				l.report(RuleUnquotedSpace, number, valueColumn, "unquoted value contains whitespace")
+			}
+		}
+	}
+	return superseded
+}
+
+// withoutExpansions removes the ${...} expansions from an unquoted value,
+// whose defaults and error messages may contain whitespace.
+func withoutExpansions(value string) string {
+	var result strings.Builder
+	depth := 0
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			if depth == 0 {
+				result.WriteString(value[i : i+2])
+			}
+			i++
+		case strings.HasPrefix(value[i:], "${"):
+			depth++
+			i++
+		case value[i] == '}' && depth > 0:
+			depth--
+		case depth == 0:
+			result.WriteByte(value[i])
+		}
+	}
+	return result.String()
+}
+
+// checkDefinitions parses content and applies the rules that need the parsed
+// variables.
+func (l *linter) checkDefinitions(content []byte, superseded map[int]bool) {
+	parser := NewParser()
+	parser.SetRecover(true)
+	parser.undefined = func(name string, pos position) {
+		l.report(RuleUndefinedReference, pos.line, pos.column, "reference to undefined variable %s", name)
+	}
+
+	variables, err := parser.read(bytes.NewReader(content), l.filename)
+	var errs ErrorList
+	if errors.As(err, &errs) {
+		for _, parseErr := range errs {
+			if !superseded[parseErr.Line] {
+				l.report(RuleSyntax, parseErr.Line, parseErr.Column, "%s", parseErr.Message)
+			}
+		}
+	} else if err != nil {
+		l.report(RuleSyntax, 1, 1, "%s", err)
+	}
+
+	firstDefinition := make(map[string]int)
+	for _, variable := range variables {
+		column := keyColumn(l.lines[variable.Line-1])
+		if first, exists := firstDefinition[variable.Name]; exists {
+			l.report(RuleDuplicateKey, variable.Line, column, "%s is already defined on line %d", variable.Name, first)
+		} else {
+			firstDefinition[variable.Name] = variable.Line
+		}
+		if !upperSnakeCaseRegex.MatchString(variable.Name) {
+			l.report(RuleKeyCase, variable.Line, column, "%s is not UPPER_SNAKE_CASE", variable.Name)
+		}
+	}
+}
+
+// splitLines splits content into lines the way the parser reads them.
+func splitLines(content string) []string {
+	content = strings.TrimSuffix(content, "\n")
+	if content == "" {
+		return nil
+	}
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// keyColumn returns the column at which the key of a definition line starts.
+func keyColumn(line string) int {
+	trimmed := strings.TrimLeftFunc(line, unicode.IsSpace)
+	column := len(line) - len(trimmed) + 1
+	if strings.HasPrefix(trimmed, "export ") {
+		rest := strings.TrimLeftFunc(trimmed[len("export "):], unicode.IsSpace)
+		column += len(trimmed) - len(rest)
+	}
+	return column
+}
+
+// isExportTypo reports whether word is a misspelling of "export": a
+// different capitalisation or one edit away from it.
+func isExportTypo(word string) bool {
+	if word == "export" {
+		return false
+	}
+	return editDistance(strings.ToLower(word), "export") <= 1
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and
+// transpositions of adjacent characters needed to turn a into b.
+func editDistance(a, b string) int {
+	previous2 := make([]int, len(b)+1)
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = min(current[j], previous2[j-2]+1)
+			}
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,101 @@
+package dotenv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	content := "# Comment \n" +
+		"export DATABASE_URL=postgres://localhost/db\n" +
+		"lower_case=1\n" +
+		"NO_EQUALS\n" +
+		"Export QUOTED=\"two words\"\n" +
+		"UNQUOTED=two words # comment\n" +
+		"REFERENCES=${DATABASE_URL}${LINT_UNDEFINED_VAR}${LINT_DEFAULTED_VAR:-x}\n" +
+		"DATABASE_URL=again\n" +
+		"MULTILINE=\"\"\"\n" +
+		"NO EQUALS IN HERE  \n" +
+		"\"\"\"\n" +
+		"BROKEN='unterminated\n" +
+		"exprot SPELLED=1\n" +
+		"LAST=1"
+
+	diagnostics := Lint(".env", []byte(content), AllRules())
+
+	expected := []Diagnostic{
+		{".env", 1, 10, RuleTrailingWhitespace, "trailing whitespace"},
+		{".env", 3, 1, RuleKeyCase, "lower_case is not UPPER_SNAKE_CASE"},
+		{".env", 4, 1, RuleMissingEquals, "line has no '=' and is ignored"},
+		{".env", 5, 1, RuleExportTypo, `"Export" should be "export"`},
+		{".env", 6, 10, RuleUnquotedSpace, "unquoted value contains whitespace"},
+		{".env", 7, 27, RuleUndefinedReference, "reference to undefined variable LINT_UNDEFINED_VAR"},
+		{".env", 8, 1, RuleDuplicateKey, "DATABASE_URL is already defined on line 2"},
+		{".env", 12, 8, RuleSyntax, "unterminated quoted string"},
+		{".env", 13, 1, RuleExportTypo, `"exprot" should be "export"`},
+		{".env", 14, 7, RuleFinalNewline, "file does not end with a newline"},
+	}
+
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("Lint() =\n%v\nwant\n%v", diagnostics, expected)
+	}
+}
+
+func TestLintExpansionsWithSpaces(t *testing.T) {
+	content := []byte("A=1\n" +
+		"C=${A:?must be set}\n" +
+		"X=${LINT_A:-a b}\n" +
+		"Y=${LINT_A:-${LINT_B:-c d}}e\n" +
+		"Z=${A} b\n")
+
+	diagnostics := Lint(".env", content, AllRules())
+
+	expected := []Diagnostic{
+		{".env", 5, 3, RuleUnquotedSpace, "unquoted value contains whitespace"},
+	}
+
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("Lint() = %v, want %v", diagnostics, expected)
+	}
+}
+
+func TestLintRuleSelection(t *testing.T) {
+	content := []byte("lower=1 \nlower=2")
+
+	rules := AllRules()
+	delete(rules, RuleKeyCase)
+	delete(rules, RuleFinalNewline)
+
+	diagnostics := Lint("test.env", content, rules)
+
+	expected := []Diagnostic{
+		{"test.env", 1, 8, RuleTrailingWhitespace, "trailing whitespace"},
+		{"test.env", 2, 1, RuleDuplicateKey, "lower is already defined on line 1"},
+	}
+
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("Lint() = %v, want %v", diagnostics, expected)
+	}
+}
+
+func TestIsExportTypo(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected bool
+	}{
+		{"export", false},
+		{"Export", true},
+		{"EXPORT", true},
+		{"exprot", true},
+		{"exort", true},
+		{"exports", true},
+		{"import", false},
+		{"set", false},
+	}
+
+	for _, tt := range tests {
+		if result := isExportTypo(tt.word); result != tt.expected {
+			t.Errorf("isExportTypo(%q) = %v, want %v", tt.word, result, tt.expected)
+		}
+	}
+}
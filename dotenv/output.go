@@ -0,0 +1,153 @@
+package dotenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// documentFormats render all variables at once, rather than one line per
+// variable like shells. Variables defined several times are merged first,
+// so the output holds the values that would be loaded.
+var documentFormats = map[string]func(variables []Variable, options RenderOptions) (string, error){
+	"json":           renderJSON,
+	"json-lines":     renderJSONLines,
+	"yaml":           renderYAML,
+	"toml":           renderTOML,
+	"docker":         renderDocker,
+	"compose":        renderCompose,
+	"configmap":      renderConfigMap,
+	"secret":         renderSecret,
+	"k8s":            renderKubernetes,
+	"systemd":        renderSystemd,
+	"systemd-dropin": renderSystemdDropIn,
+	"github":         renderGitHub,
+	"gitlab-dotenv":  renderGitLabDotenv,
+	"template":       renderTemplate,
+}
+
+// RenderOptions configures the output formats that need more than the
+// variables. The zero value is valid for all formats.
+type RenderOptions struct {
+	// Name and Namespace are the metadata of Kubernetes manifests. A name is
+	// required for them.
+	Name      string
+	Namespace string
+	// Labels are added to Kubernetes manifests.
+	Labels map[string]string
+	// StringData writes the values of Secrets as plain text in stringData
+	// instead of base64 in data.
+	StringData bool
+	// SecretPattern selects the variables that go into the Secret rather
+	// than the ConfigMap in the k8s format, and the variables masked by
+	// GitHubMasks. SecretPattern is used if nil.
+	SecretPattern *regexp.Regexp
+	// Template is executed by the template format, see ParseTemplate.
+	Template *template.Template
+}
+
+// Render renders variables in the given format with default options. See
+// RenderWithOptions.
+func Render(variables []Variable, format string) (string, error) {
+	return RenderWithOptions(variables, format, RenderOptions{})
+}
+
+// RenderWithOptions renders variables for a shell or output mode supported
+// by TransformToShellSyntax, one line per variable, or as a document in one
+// of the formats:
+//
+//   - json: an object mapping names to values
+//   - json-lines: one {"name", "value", "file", "line"} object per line
+//   - yaml: a mapping of names to strings, multiline values as block scalars
+//   - toml: a table of strings, multiline values as multi-line strings
+//   - docker: an env file for docker run --env-file, without quoting
+//   - compose: a Docker Compose environment block with $ escaped as $$
+//   - configmap: a Kubernetes ConfigMap manifest
+//   - secret: a Kubernetes Secret manifest
+//   - k8s: a ConfigMap and a Secret, split by options.SecretPattern
+//   - systemd: a file for the EnvironmentFile= setting of systemd units
+//   - systemd-dropin: a drop-in unit file with Environment= settings
+//   - github: a file for $GITHUB_ENV in GitHub Actions, see also GitHubMasks
+//   - gitlab-dotenv: a file for artifacts:reports:dotenv in GitLab CI
+//   - template: the output of options.Template
+//
+// It returns an error for unknown formats.
+func RenderWithOptions(variables []Variable, format string, options RenderOptions) (string, error) {
+	if render, ok := documentFormats[format]; ok {
+		return render(Merge(variables), options)
+	}
+
+	if TransformToShellSyntax(Variable{Name: "NAME", Value: "value"}, format) == "" {
+		return "", fmt.Errorf("unknown output format %q", format)
+	}
+	lines := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		lines = append(lines, TransformToShellSyntax(variable, format))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// marshalJSON encodes v without escaping HTML characters, which is
+// unnecessary outside of HTML and makes values harder to read.
+func marshalJSON(v any) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buffer.Bytes(), []byte("\n")), nil
+}
+
+// renderJSON renders an object mapping names to values, in the order the
+// variables are defined.
+func renderJSON(variables []Variable, _ RenderOptions) (string, error) {
+	if len(variables) == 0 {
+		return "{}\n", nil
+	}
+
+	var result strings.Builder
+	result.WriteString("{\n")
+	for i, variable := range variables {
+		name, err := marshalJSON(variable.Name)
+		if err != nil {
+			return "", err
+		}
+		value, err := marshalJSON(variable.Value)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&result, "  %s: %s", name, value)
+		if i < len(variables)-1 {
+			result.WriteByte(',')
+		}
+		result.WriteByte('\n')
+	}
+	result.WriteString("}\n")
+	return result.String(), nil
+}
+
+// jsonVariable is a Variable in the json-lines format.
+type jsonVariable struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	File  string `json:"file"`
+	Line  int    `json:"line"`
+}
+
+// renderJSONLines renders one JSON object per variable and line.
+func renderJSONLines(variables []Variable, _ RenderOptions) (string, error) {
+	var result strings.Builder
+	for _, variable := range variables {
+		line, err := marshalJSON(jsonVariable(variable))
+		if err != nil {
+			return "", err
+		}
+		result.Write(line)
+		result.WriteByte('\n')
+	}
+	return result.String(), nil
+}
@@ -0,0 +1,210 @@
+package dotenv
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var outputVariables = []Variable{
+	{Name: "HOST", Value: "localhost", File: ".env", Line: 1},
+	{Name: "PORT", Value: "3000", File: ".env", Line: 2},
+	{Name: "KEY", Value: "-----BEGIN KEY-----\nabc\n-----END KEY-----", File: ".env", Line: 3},
+	{Name: "HTML", Value: `<a href="x">&</a>`, File: ".env", Line: 6},
+	{Name: "PORT", Value: "4000", File: ".env.local", Line: 1},
+}
+
+func TestRenderJSON(t *testing.T) {
+	output, err := Render(outputVariables, "json")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	expected := "{\n" +
+		"  \"HOST\": \"localhost\",\n" +
+		"  \"PORT\": \"4000\",\n" +
+		"  \"KEY\": \"-----BEGIN KEY-----\\nabc\\n-----END KEY-----\",\n" +
+		"  \"HTML\": \"<a href=\\\"x\\\">&</a>\"\n" +
+		"}\n"
+	if output != expected {
+		t.Errorf("Render() = %s, want %s", output, expected)
+	}
+
+	var decoded map[string]string
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("Render() output is not valid JSON: %v", err)
+	}
+	if decoded["KEY"] != outputVariables[2].Value {
+		t.Errorf("KEY = %q, want %q", decoded["KEY"], outputVariables[2].Value)
+	}
+
+	if output, _ := Render(nil, "json"); output != "{}\n" {
+		t.Errorf("Render(nil) = %q, want %q", output, "{}\n")
+	}
+}
+
+func TestRenderJSONLines(t *testing.T) {
+	output, err := Render(outputVariables, "json-lines")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Render() returned %d lines, want 4:\n%s", len(lines), output)
+	}
+	expected := `{"name":"PORT","value":"4000","file":".env.local","line":1}`
+	if lines[1] != expected {
+		t.Errorf("line 2 = %s, want %s", lines[1], expected)
+	}
+	for _, line := range lines {
+		var decoded jsonVariable
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Errorf("line %s is not valid JSON: %v", line, err)
+		}
+	}
+}
+
+func TestRenderShell(t *testing.T) {
+	output, err := Render(outputVariables[:2], "bash")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if expected := "export HOST='localhost'\nexport PORT='3000'"; output != expected {
+		t.Errorf("Render() = %q, want %q", output, expected)
+	}
+
+	for _, format := range []string{"", "unknown"} {
+		if _, err := Render(outputVariables, format); err == nil {
+			t.Errorf("Render(%q) succeeded, want an error", format)
+		}
+	}
+}
+
+func TestRenderYAML(t *testing.T) {
+	variables := []Variable{
+		{Name: "HOST", Value: "localhost"},
+		{Name: "PORT", Value: "3000"},
+		{Name: "ON", Value: "yes"},
+		{Name: "PATH_VALUE", Value: "/usr/local/bin"},
+		{Name: "URL", Value: "http://localhost:8080/path"},
+		{Name: "MAPPING", Value: "a: b"},
+		{Name: "COMMENT", Value: "a #b"},
+		{Name: "EMPTY", Value: ""},
+		{Name: "KEY", Value: "-----BEGIN KEY-----\nabc\n-----END KEY-----\n"},
+		{Name: "NO_FINAL_NEWLINE", Value: "l1\nl2"},
+		{Name: "NEWLINES", Value: "l1\n\n"},
+		{Name: "INDENTED", Value: "  l1\nl2"},
+		{Name: "CARRIAGE", Value: "l1\r\nl2"},
+	}
+
+	output, err := Render(variables, "yaml")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := `HOST: localhost
+PORT: "3000"
+"ON": "yes"
+PATH_VALUE: /usr/local/bin
+URL: http://localhost:8080/path
+MAPPING: "a: b"
+COMMENT: "a #b"
+EMPTY: ""
+KEY: |
+  -----BEGIN KEY-----
+  abc
+  -----END KEY-----
+NO_FINAL_NEWLINE: |-
+  l1
+  l2
+NEWLINES: |+
+  l1
+
+INDENTED: |2-
+    l1
+  l2
+CARRIAGE: "l1\r\nl2"
+`
+	if output != expected {
+		t.Errorf("Render() =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestRenderTOML(t *testing.T) {
+	variables := []Variable{
+		{Name: "HOST", Value: "localhost"},
+		{Name: "QUOTES", Value: `say "hi" \o/`},
+		{Name: "KEY", Value: "-----BEGIN KEY-----\nabc\n-----END KEY-----\n"},
+		{Name: "TRIPLE", Value: "\"\"\"\n\"\"\""},
+		{Name: "CONTROL", Value: "tab\tcr\rbell\a"},
+	}
+
+	output, err := Render(variables, "toml")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := `HOST = "localhost"
+QUOTES = "say \"hi\" \\o/"
+KEY = """
+-----BEGIN KEY-----
+abc
+-----END KEY-----
+"""
+TRIPLE = """
+\"\"\"
+\"\"\""""
+CONTROL = "tab	cr\rbell\u0007"
+`
+	if output != expected {
+		t.Errorf("Render() =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestRenderDocker(t *testing.T) {
+	variables := []Variable{
+		{Name: "HOST", Value: "localhost"},
+		{Name: "QUOTED", Value: `"kept" as 'is' $HOME # too`},
+		{Name: "SPACES", Value: "  padded  "},
+		{Name: "EMPTY", Value: ""},
+	}
+
+	output, err := Render(variables, "docker")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := "HOST=localhost\n" +
+		"QUOTED=\"kept\" as 'is' $HOME # too\n" +
+		"SPACES=  padded  \n" +
+		"EMPTY=\n"
+	if output != expected {
+		t.Errorf("Render() = %q, want %q", output, expected)
+	}
+
+	variables = append(variables, Variable{Name: "KEY", Value: "l1\nl2"}, Variable{Name: "CR", Value: "x\r"})
+	_, err = Render(variables, "docker")
+	if err == nil || !strings.Contains(err.Error(), "KEY, CR") {
+		t.Errorf("Render() error = %v, want an error naming KEY and CR", err)
+	}
+}
+
+func TestRenderCompose(t *testing.T) {
+	variables := []Variable{
+		{Name: "HOST", Value: "localhost"},
+		{Name: "PRICE", Value: "$5 and ${X}"},
+		{Name: "KEY", Value: "l1\n$l2\n"},
+	}
+
+	output, err := Render(variables, "compose")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := "environment:\n" +
+		"  HOST: localhost\n" +
+		"  PRICE: \"$$5 and $${X}\"\n" +
+		"  KEY: |\n" +
+		"    l1\n" +
+		"    $$l2\n"
+	if output != expected {
+		t.Errorf("Render() = %q, want %q", output, expected)
+	}
+}
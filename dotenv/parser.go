@@ -0,0 +1,397 @@
+package dotenv
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Variable is a single name/value pair read from a dotenv file.
+type Variable struct {
+	Name  string
+	Value string
+	// File is the file the variable was read from, empty if it was not read
+	// from a file.
+	File string
+	// Line is the line the definition starts on, counted from 1 within File.
+	Line int
+}
+
+// Parser reads dotenv syntax line by line. Variables parsed earlier are
+// available to later lines through ${VAR} interpolation, including those
+// from files parsed earlier by the same Parser.
+type Parser struct {
+	variables []Variable
+	lines     []string
+	position  int
+	// filename and lineOffset describe the input currently being parsed:
+	// its name and the index in lines at which it starts.
+	filename   string
+	lineOffset int
+	recovering bool
+	// undefined is called for plain references to variables that are not
+	// defined, if set.
+	undefined func(name string, pos position)
+	// defaults are seen by references to variables that are not defined,
+	// see SetDefaults.
+	defaults []Variable
+}
+
+// NewParser returns an empty Parser.
+func NewParser() *Parser {
+	return &Parser{
+		variables: make([]Variable, 0),
+		lines:     make([]string, 0),
+		position:  0,
+	}
+}
+
+var validVarNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// definitionRegex matches lines that start a variable definition. It is used
+// to find a place to resume parsing after an unterminated multiline string.
+var definitionRegex = regexp.MustCompile(`^\s*(export\s+)?[a-zA-Z_][a-zA-Z0-9_]*\s*=`)
+
+func isValidVariableName(name string) bool {
+	return validVarNameRegex.MatchString(name)
+}
+
+// IsValidName reports whether name can be defined in a dotenv file: a letter
+// or underscore followed by letters, digits and underscores.
+func IsValidName(name string) bool {
+	return isValidVariableName(name)
+}
+
+// SetRecover controls what happens when a line cannot be parsed. By default
+// parsing stops at the first error. When recovering, the Parser skips the
+// line (or the rest of an unterminated multiline string), carries on, and
+// returns the variables that could be parsed together with an ErrorList.
+func (p *Parser) SetRecover(enabled bool) {
+	p.recovering = enabled
+}
+
+// SetDefaults sets the values that references see for variables the input
+// has not defined, such as the defaults of a Schema. They take precedence
+// over the environment, since the variables are loaded with these values.
+// The defaults are not returned as variables.
+func (p *Parser) SetDefaults(defaults []Variable) {
+	p.defaults = defaults
+}
+
+// ParseFile reads and parses the dotenv file at filename.
+func (p *Parser) ParseFile(filename string) ([]Variable, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return p.read(file, filename)
+}
+
+// ParseReader reads all lines from r and parses them.
+func (p *Parser) ParseReader(r io.Reader) ([]Variable, error) {
+	return p.read(r, "")
+}
+
+func (p *Parser) read(r io.Reader, filename string) ([]Variable, error) {
+	p.filename = filename
+	p.lineOffset = len(p.lines)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p.lines = append(p.lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.Parse()
+}
+
+// Parse parses the lines read so far and returns all variables in the order
+// they were defined.
+func (p *Parser) Parse() ([]Variable, error) {
+	var errs ErrorList
+	for p.position < len(p.lines) {
+		if err := p.parseLine(); err != nil {
+			var parseErr *ParseError
+			if !p.recovering || !errors.As(err, &parseErr) {
+				return nil, err
+			}
+			errs = append(errs, parseErr)
+			p.skipAfterError(parseErr)
+		}
+		p.position++
+	}
+	if len(errs) > 0 {
+		return p.variables, errs
+	}
+	return p.variables, nil
+}
+
+// skipAfterError moves to the last line belonging to the definition that
+// caused err, so that parsing resumes after it.
+func (p *Parser) skipAfterError(err *ParseError) {
+	if err.Kind != UnterminatedMultiline {
+		return
+	}
+	// The string ran to the end of the input; resume at the next line that
+	// looks like a definition instead
+	p.position = p.lineOffset + err.Line - 1
+	for p.position+1 < len(p.lines) && !definitionRegex.MatchString(p.lines[p.position+1]) {
+		p.position++
+	}
+}
+
+// lineNumber returns the line number of the current position within the
+// current input.
+func (p *Parser) lineNumber() int {
+	return p.position - p.lineOffset + 1
+}
+
+func (p *Parser) parseLine() error {
+	line := p.lines[p.position]
+	lineNumber := p.lineNumber()
+
+	if strings.TrimSpace(line) == "" {
+		return nil
+	}
+
+	if strings.TrimSpace(line)[0] == '#' {
+		return nil
+	}
+
+	// Track where the remaining text starts in the original line
+	trimmed := strings.TrimSpace(line)
+	pos := position{line: lineNumber, column: 1}.advance(line[:strings.Index(line, trimmed)])
+	line = trimmed
+
+	if strings.HasPrefix(line, "export ") {
+		trimmed = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		pos = pos.advance(line[:len(line)-len(trimmed)])
+		line = trimmed
+	}
+
+	eqIndex := strings.IndexByte(line, '=')
+	if eqIndex == -1 {
+		return nil
+	}
+
+	keyPart := strings.TrimSpace(line[:eqIndex])
+	valuePart := line[eqIndex+1:]
+
+	if !isValidVariableName(keyPart) {
+		return p.errorAt(pos, InvalidName, fmt.Sprintf("invalid variable name: %s", keyPart))
+	}
+
+	value, err := p.parseValue(valuePart, pos.advance(line[:eqIndex+1]))
+	if err != nil {
+		return err
+	}
+
+	p.variables = append(p.variables, Variable{Name: keyPart, Value: value, File: p.filename, Line: lineNumber})
+	return nil
+}
+
+// parseValue parses the text after the equals sign, which starts at pos
+func (p *Parser) parseValue(valuePart string, pos position) (string, error) {
+	trimmed := strings.TrimLeftFunc(valuePart, unicode.IsSpace)
+	pos = pos.advance(valuePart[:len(valuePart)-len(trimmed)])
+	valuePart = trimmed
+
+	if len(valuePart) == 0 {
+		return "", nil
+	}
+
+	if strings.HasPrefix(valuePart, `"""`) || strings.HasPrefix(valuePart, `'''`) {
+		return p.parseMultilineValue(valuePart, pos)
+	}
+
+	if len(valuePart) > 0 && (valuePart[0] == '"' || valuePart[0] == '\'') {
+		return p.parseQuotedValue(valuePart, pos)
+	}
+
+	return p.parseUnquotedValue(valuePart, pos)
+}
+
+func (p *Parser) parseMultilineValue(valuePart string, pos position) (string, error) {
+	delimiter := valuePart[:3]
+	interpolate := delimiter == `"""`
+	start := pos
+	valuePart = valuePart[3:]
+
+	var result strings.Builder
+	// The content starts on the next line unless something follows the
+	// opening delimiter
+	contentPos := position{line: pos.line + 1, column: 1}
+
+	// If the rest of the line after the opening delimiter is not empty, include it
+	if strings.TrimSpace(valuePart) != "" {
+		contentPos = pos.advance(delimiter)
+		if strings.HasSuffix(valuePart, delimiter) {
+			// Single line triple-quoted string
+			content := valuePart[:len(valuePart)-3]
+			if interpolate {
+				return p.processEscapesAndInterpolation(content, contentPos)
+			}
+			return content, nil
+		}
+		result.WriteString(valuePart)
+		result.WriteString("\n")
+	}
+
+	// Continue reading lines until we find the closing delimiter
+	p.position++
+	for p.position < len(p.lines) {
+		line := p.lines[p.position]
+		if strings.HasSuffix(line, delimiter) {
+			// Found closing delimiter
+			content := line[:len(line)-3]
+			result.WriteString(content)
+			break
+		}
+		result.WriteString(line)
+		result.WriteString("\n")
+		p.position++
+	}
+
+	if p.position >= len(p.lines) {
+		return "", p.errorAt(start, UnterminatedMultiline, "unterminated multiline string")
+	}
+
+	finalValue := result.String()
+	if interpolate {
+		return p.processEscapesAndInterpolation(finalValue, contentPos)
+	}
+	return finalValue, nil
+}
+
+// parseQuotedValue parses single or double quoted values
+func (p *Parser) parseQuotedValue(valuePart string, pos position) (string, error) {
+	quote := valuePart[0]
+	interpolate := quote == '"'
+
+	var result strings.Builder
+	i := 1 // Skip opening quote
+
+	for i < len(valuePart) {
+		if valuePart[i] == quote {
+			// Found closing quote
+			finalValue := result.String()
+			if interpolate {
+				return p.processEscapesAndInterpolation(finalValue, pos.advance(valuePart[:1]))
+			}
+			return finalValue, nil
+		}
+
+		if valuePart[i] == '\\' && i+1 < len(valuePart) {
+			if interpolate {
+				// Keep escape sequences intact; they are decoded together with
+				// interpolation once the closing quote has been found
+				result.WriteString(valuePart[i : i+2])
+				i += 2
+			} else {
+				// In single quotes, only escape single quotes and backslashes
+				nextChar := valuePart[i+1]
+				if nextChar == '\'' || nextChar == '\\' {
+					result.WriteByte(nextChar)
+					i += 2
+				} else {
+					result.WriteByte('\\')
+					i++
+				}
+			}
+		} else {
+			result.WriteByte(valuePart[i])
+			i++
+		}
+	}
+
+	return "", p.errorAt(pos, UnterminatedQuote, "unterminated quoted string")
+}
+
+// parseUnquotedValue parses unquoted values
+func (p *Parser) parseUnquotedValue(valuePart string, pos position) (string, error) {
+	// Find comment start (not within quotes)
+	commentIndex := -1
+	for i, char := range valuePart {
+		if char == '#' {
+			commentIndex = i
+			break
+		}
+	}
+
+	if commentIndex != -1 {
+		valuePart = valuePart[:commentIndex]
+	}
+
+	value := strings.TrimRightFunc(valuePart, unicode.IsSpace)
+	return p.processEscapesAndInterpolation(value, pos)
+}
+
+// processEscapesAndInterpolation decodes escape sequences and performs
+// variable interpolation in a single pass, so that an escaped \$ is never
+// taken as the start of a reference. pos is where value starts in the input
+func (p *Parser) processEscapesAndInterpolation(value string, pos position) (string, error) {
+	var result strings.Builder
+	i := 0
+
+	for i < len(value) {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			i += decodeEscape(value[i:], &result)
+		case value[i] == '$':
+			consumed, err := p.interpolateReference(value[i:], &result, pos.advance(value[:i]))
+			if err != nil {
+				return "", err
+			}
+			i += consumed
+		default:
+			result.WriteByte(value[i])
+			i++
+		}
+	}
+
+	return result.String(), nil
+}
+
+// decodeEscape writes the character encoded by the escape sequence at the
+// start of value to result and returns the number of bytes it spans
+func decodeEscape(value string, result *strings.Builder) int {
+	nextChar := value[1]
+	switch nextChar {
+	case 'n':
+		result.WriteByte('\n')
+	case 'r':
+		result.WriteByte('\r')
+	case 't':
+		result.WriteByte('\t')
+	case 'f':
+		result.WriteByte('\f')
+	case 'b':
+		result.WriteByte('\b')
+	case 'u':
+		if len(value) >= 6 {
+			hexCode := value[2:6]
+			if codepoint, err := strconv.ParseInt(hexCode, 16, 32); err == nil {
+				result.WriteRune(rune(codepoint))
+				return 6 // Skip '\', 'u' and 4 hex digits
+			}
+		}
+		// Invalid unicode escape, treat literally
+		result.WriteByte(nextChar)
+	default:
+		// Any other character after backslash (including quotes, backslashes
+		// and $) is treated literally
+		result.WriteByte(nextChar)
+	}
+	return 2
+}
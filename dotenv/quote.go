@@ -0,0 +1,72 @@
+package dotenv
+
+import "strings"
+
+// QuoteForShell quotes value so that evaluating the result in the given
+// shell yields exactly value. Unknown shells get POSIX quoting.
+func QuoteForShell(shellName, value string) string {
+	switch shellName {
+	case "fish":
+		return quoteFish(value)
+	case "powershell":
+		return quotePowerShell(value)
+	case "cmd":
+		return quoteCmd(value)
+	default:
+		return quotePOSIX(value)
+	}
+}
+
+// quotePOSIX wraps value in single quotes, inside of which sh, bash and zsh
+// do not expand anything. A single quote is written by closing the quoted
+// string, adding an escaped quote and reopening it.
+func quotePOSIX(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// quoteFish wraps value in single quotes. Fish still honours \\ and \' inside
+// single quotes, so backslashes have to be escaped as well.
+func quoteFish(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + replacer.Replace(value) + "'"
+}
+
+// quotePowerShell produces a verbatim (single-quoted) string. PowerShell
+// treats the typographic single quotes as quotes too, so every kind is
+// doubled.
+func quotePowerShell(value string) string {
+	var result strings.Builder
+	result.WriteByte('\'')
+	for _, char := range value {
+		switch char {
+		case '\'', '‘', '’', '‚', '‛':
+			result.WriteRune(char)
+		}
+		result.WriteRune(char)
+	}
+	result.WriteByte('\'')
+	return result.String()
+}
+
+// quoteCmd escapes value for a `set NAME=value` line in a batch file. The
+// special characters are escaped with ^ (including the double quote, so the
+// parser never enters quoted mode in which ^ would be kept), % is doubled,
+// and a line feed is written as ^ followed by an empty line. Carriage returns
+// cannot be represented and empty values unset the variable.
+func quoteCmd(value string) string {
+	var result strings.Builder
+	for _, char := range value {
+		switch char {
+		case '^', '&', '|', '<', '>', '(', ')', '"':
+			result.WriteByte('^')
+			result.WriteRune(char)
+		case '%':
+			result.WriteString("%%")
+		case '\n':
+			result.WriteString("^\n\n")
+		default:
+			result.WriteRune(char)
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,99 @@
+package dotenv
+
+import (
+	"os/exec"
+	"testing"
+)
+
+var quoteTestValues = []string{
+	"",
+	"simple",
+	"with spaces",
+	"$HOME and ${USER}",
+	"`whoami` $(id)",
+	"history!expansion",
+	"it's",
+	`back\slash \n \'`,
+	"line1\nline2\n",
+	`"double" & | < > ( ) ^ %PATH%`,
+	"unicode: äöü ’",
+}
+
+func TestQuoteForShell(t *testing.T) {
+	tests := []struct {
+		shell    string
+		value    string
+		expected string
+	}{
+		{"bash", "simple", `'simple'`},
+		{"bash", "$HOME", `'$HOME'`},
+		{"bash", "it's", `'it'\''s'`},
+		{"bash", "line1\nline2", "'line1\nline2'"},
+		{"zsh", "a!b", `'a!b'`},
+		{"fish", "it's", `'it\'s'`},
+		{"fish", `back\slash`, `'back\\slash'`},
+		{"fish", "$HOME", `'$HOME'`},
+		{"powershell", "it's", `'it''s'`},
+		{"powershell", "$env:HOME `n", "'$env:HOME `n'"},
+		{"powershell", "it’s", "'it’’s'"},
+		{"cmd", "a&b|c", `a^&b^|c`},
+		{"cmd", `"quoted" (x)`, `^"quoted^" ^(x^)`},
+		{"cmd", "100%", "100%%"},
+		{"cmd", "a^b", "a^^b"},
+		{"cmd", "line1\nline2", "line1^\n\nline2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell+" "+tt.value, func(t *testing.T) {
+			result := QuoteForShell(tt.shell, tt.value)
+			if result != tt.expected {
+				t.Errorf("QuoteForShell(%q, %q) = %q, want %q", tt.shell, tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestQuoteForShellRoundTrip(t *testing.T) {
+	shells := []struct {
+		name    string
+		command func(assignment string) *exec.Cmd
+	}{
+		{"sh", func(assignment string) *exec.Cmd {
+			return exec.Command("sh", "-c", assignment+`; printf '%s' "$VALUE"`)
+		}},
+		{"bash", func(assignment string) *exec.Cmd {
+			return exec.Command("bash", "-c", assignment+`; printf '%s' "$VALUE"`)
+		}},
+		{"zsh", func(assignment string) *exec.Cmd {
+			return exec.Command("zsh", "-c", assignment+`; printf '%s' "$VALUE"`)
+		}},
+		{"fish", func(assignment string) *exec.Cmd {
+			return exec.Command("fish", "-c", assignment+`; printf '%s' "$VALUE"`)
+		}},
+		{"powershell", func(assignment string) *exec.Cmd {
+			return exec.Command("pwsh", "-NoProfile", "-Command", assignment+`; [Console]::Out.Write($env:VALUE)`)
+		}},
+	}
+
+	for _, shell := range shells {
+		t.Run(shell.name, func(t *testing.T) {
+			if _, err := exec.LookPath(shell.command("").Path); err != nil {
+				t.Skipf("%s is not installed", shell.name)
+			}
+			for _, value := range quoteTestValues {
+				if value == "" && shell.name == "powershell" {
+					// Assigning an empty string removes the variable
+					continue
+				}
+				assignment := TransformToShellSyntax(Variable{Name: "VALUE", Value: value}, shell.name)
+				output, err := shell.command(assignment).Output()
+				if err != nil {
+					t.Fatalf("%s failed for %q: %v", shell.name, assignment, err)
+				}
+				if string(output) != value {
+					t.Errorf("%s evaluated %q to %q, want %q", shell.name, assignment, output, value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package dotenv
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type is the type of a variable in a Schema.
+type Type string
+
+// Types supported by Schema.
+const (
+	TypeString   Type = "string"
+	TypeInt      Type = "int"
+	TypeBool     Type = "bool"
+	TypeURL      Type = "url"
+	TypePort     Type = "port"
+	TypeDuration Type = "duration"
+	TypeEnum     Type = "enum"
+	TypeRegex    Type = "regex"
+)
+
+// Field describes one variable of a Schema.
+type Field struct {
+	// Type is the type of the value, TypeString if empty.
+	Type Type `json:"type"`
+	// Required variables must be defined with a non-empty value, unless
+	// they have a default.
+	Required bool `json:"required"`
+	// Default is the value used when the variable is not defined.
+	Default *string `json:"default"`
+	// Description documents the variable.
+	Description string `json:"description"`
+	// Values lists the allowed values of a TypeEnum variable.
+	Values []string `json:"values"`
+	// Pattern is the regular expression a TypeRegex value must match
+	// completely.
+	Pattern string `json:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// Schema declares the type and constraints of variables. Variables that the
+// schema does not mention are not checked.
+type Schema struct {
+	// File is the file the schema was loaded from, if any.
+	File   string
+	Fields map[string]*Field
+}
+
+// ValidationError is a variable that does not match its Schema field.
+type ValidationError struct {
+	Name string
+	// File and Line locate the definition, both empty if the variable is
+	// not defined.
+	File    string
+	Line    int
+	Message string
+}
+
+// Error formats the error as file:line: NAME: message.
+func (e *ValidationError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("%s: %s", e.Name, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", e.File, e.Line, e.Name, e.Message)
+}
+
+// ParseSchema reads a schema from r. The schema is a JSON object mapping
+// variable names to fields:
+//
+//	{
+//	  "PORT": {"type": "port", "default": "3000", "description": "HTTP port"},
+//	  "LOG_LEVEL": {"type": "enum", "values": ["debug", "info", "warn"]},
+//	  "DATABASE_URL": {"type": "url", "required": true}
+//	}
+func ParseSchema(r io.Reader) (*Schema, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	schema := &Schema{}
+	if err := decoder.Decode(&schema.Fields); err != nil {
+		return nil, err
+	}
+
+	for _, name := range schema.names() {
+		field := schema.Fields[name]
+		if !isValidVariableName(name) {
+			return nil, fmt.Errorf("%s: invalid variable name", name)
+		}
+		if field == nil {
+			return nil, fmt.Errorf("%s: field must be an object", name)
+		}
+		switch field.Type {
+		case "":
+			field.Type = TypeString
+		case TypeString, TypeInt, TypeBool, TypeURL, TypePort, TypeDuration:
+		case TypeEnum:
+			if len(field.Values) == 0 {
+				return nil, fmt.Errorf("%s: enum without values", name)
+			}
+		case TypeRegex:
+			if field.Pattern == "" {
+				return nil, fmt.Errorf("%s: regex without pattern", name)
+			}
+			pattern, err := regexp.Compile(`^(?:` + field.Pattern + `)$`)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid pattern: %w", name, err)
+			}
+			field.pattern = pattern
+		default:
+			return nil, fmt.Errorf("%s: unknown type %q", name, field.Type)
+		}
+		if field.Default != nil {
+			if err := field.check(*field.Default); err != nil {
+				return nil, fmt.Errorf("%s: invalid default: %w", name, err)
+			}
+		}
+	}
+	return schema, nil
+}
+
+// LoadSchema reads the schema in filename with ParseSchema.
+func LoadSchema(filename string) (*Schema, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	schema, err := ParseSchema(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	schema.File = filename
+	return schema, nil
+}
+
+// Validate checks variables against the schema and returns an error for
+// every variable that does not match, ordered by name. When a variable is
+// defined several times the last definition counts. Empty values of optional
+// variables are not checked.
+func (s *Schema) Validate(variables []Variable) []*ValidationError {
+	defined := make(map[string]Variable, len(variables))
+	for _, variable := range variables {
+		defined[variable.Name] = variable
+	}
+
+	var errs []*ValidationError
+	for _, name := range s.names() {
+		field := s.Fields[name]
+		variable, exists := defined[name]
+		switch {
+		case !exists:
+			if field.Required && field.Default == nil {
+				errs = append(errs, &ValidationError{Name: name, Message: "required variable is not set"})
+			}
+		case variable.Value == "":
+			if field.Required {
+				errs = append(errs, &ValidationError{Name: name, File: variable.File, Line: variable.Line, Message: "required variable is empty"})
+			}
+		default:
+			if err := field.check(variable.Value); err != nil {
+				errs = append(errs, &ValidationError{Name: name, File: variable.File, Line: variable.Line, Message: err.Error()})
+			}
+		}
+	}
+	return errs
+}
+
+// ApplyDefaults returns variables followed by the defaults of the fields
+// that are not defined in it, ordered by name. Defaults are attributed to
+// the schema file.
+func (s *Schema) ApplyDefaults(variables []Variable) []Variable {
+	defined := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		defined[variable.Name] = true
+	}
+
+	for _, name := range s.names() {
+		if field := s.Fields[name]; field.Default != nil && !defined[name] {
+			variables = append(variables, Variable{Name: name, Value: *field.Default, File: s.File})
+		}
+	}
+	return variables
+}
+
+// names returns the names of the fields in order.
+func (s *Schema) names() []string {
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// check returns an error if value is not valid for the field.
+func (f *Field) check(value string) error {
+	switch f.Type {
+	case TypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+	case TypeBool:
+		if _, err := parseBool(value); err != nil {
+			return err
+		}
+	case TypeURL:
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" {
+			return fmt.Errorf("%q is not an absolute URL", value)
+		}
+	case TypePort:
+		if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+			return fmt.Errorf("%q is not a port number between 1 and 65535", value)
+		}
+	case TypeDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("%q is not a duration such as 30s or 1h30m", value)
+		}
+	case TypeEnum:
+		for _, allowed := range f.Values {
+			if value == allowed {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of %s", value, strings.Join(f.Values, ", "))
+	case TypeRegex:
+		if !f.pattern.MatchString(value) {
+			return fmt.Errorf("%q does not match %s", value, f.Pattern)
+		}
+	}
+	return nil
+}
+
+// parseBool accepts the usual spellings of booleans in configuration:
+// true/false, yes/no, on/off and 1/0, in any case.
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "true", "yes", "on", "1":
+		return true, nil
+	case "false", "no", "off", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is not a boolean", value)
+}
@@ -0,0 +1,114 @@
+package dotenv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSchema = `{
+	"HOST": {"description": "Host name"},
+	"PORT": {"type": "port", "default": "3000"},
+	"WORKERS": {"type": "int"},
+	"DEBUG": {"type": "bool", "default": "false"},
+	"DATABASE_URL": {"type": "url", "required": true},
+	"TIMEOUT": {"type": "duration"},
+	"LOG_LEVEL": {"type": "enum", "values": ["debug", "info", "warn"]},
+	"REGION": {"type": "regex", "pattern": "[a-z]+-[0-9]"},
+	"SECRET": {"required": true}
+}`
+
+func TestSchemaValidate(t *testing.T) {
+	schema, err := ParseSchema(strings.NewReader(testSchema))
+	if err != nil {
+		t.Fatalf("ParseSchema() error = %v", err)
+	}
+
+	valid := []Variable{
+		{Name: "HOST", Value: "localhost"},
+		{Name: "WORKERS", Value: "-4"},
+		{Name: "DEBUG", Value: "Yes"},
+		{Name: "DATABASE_URL", Value: "postgres://localhost/app"},
+		{Name: "TIMEOUT", Value: "1m30s"},
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "REGION", Value: "eu-1"},
+		{Name: "SECRET", Value: "s3cr3t"},
+		{Name: "UNKNOWN", Value: "anything"},
+	}
+	if errs := schema.Validate(valid); len(errs) != 0 {
+		t.Errorf("Validate() = %v, want no errors", errs)
+	}
+
+	invalid := []Variable{
+		{Name: "PORT", Value: "70000", File: ".env", Line: 1},
+		{Name: "WORKERS", Value: "four", File: ".env", Line: 2},
+		{Name: "DEBUG", Value: "maybe", File: ".env", Line: 3},
+		{Name: "DATABASE_URL", Value: "localhost", File: ".env", Line: 4},
+		{Name: "TIMEOUT", Value: "90", File: ".env", Line: 5},
+		{Name: "LOG_LEVEL", Value: "trace", File: ".env", Line: 6},
+		{Name: "REGION", Value: "eu-1x", File: ".env", Line: 7},
+		{Name: "SECRET", Value: "", File: ".env", Line: 8},
+		{Name: "HOST", Value: "", File: ".env", Line: 9},
+	}
+	var messages []string
+	for _, err := range schema.Validate(invalid) {
+		messages = append(messages, err.Error())
+	}
+	expected := []string{
+		`.env:4: DATABASE_URL: "localhost" is not an absolute URL`,
+		`.env:3: DEBUG: "maybe" is not a boolean`,
+		`.env:6: LOG_LEVEL: "trace" is not one of debug, info, warn`,
+		`.env:1: PORT: "70000" is not a port number between 1 and 65535`,
+		`.env:7: REGION: "eu-1x" does not match [a-z]+-[0-9]`,
+		`.env:8: SECRET: required variable is empty`,
+		`.env:5: TIMEOUT: "90" is not a duration such as 30s or 1h30m`,
+		`.env:2: WORKERS: "four" is not an integer`,
+	}
+	if !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Validate() = %q, want %q", messages, expected)
+	}
+
+	errs := schema.Validate(nil)
+	if len(errs) != 2 || errs[0].Error() != "DATABASE_URL: required variable is not set" || errs[1].Name != "SECRET" {
+		t.Errorf("Validate(nil) = %v", errs)
+	}
+}
+
+func TestSchemaApplyDefaults(t *testing.T) {
+	schema, err := ParseSchema(strings.NewReader(testSchema))
+	if err != nil {
+		t.Fatalf("ParseSchema() error = %v", err)
+	}
+	schema.File = ".env.schema"
+
+	variables := schema.ApplyDefaults([]Variable{{Name: "PORT", Value: "8080", File: ".env", Line: 1}})
+	expected := []Variable{
+		{Name: "PORT", Value: "8080", File: ".env", Line: 1},
+		{Name: "DEBUG", Value: "false", File: ".env.schema"},
+	}
+	if !reflect.DeepEqual(variables, expected) {
+		t.Errorf("ApplyDefaults() = %v, want %v", variables, expected)
+	}
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+	schemas := map[string]string{
+		"invalid json":     `{"A": `,
+		"unknown type":     `{"A": {"type": "float"}}`,
+		"unknown field":    `{"A": {"typ": "int"}}`,
+		"invalid name":     `{"1A": {}}`,
+		"null field":       `{"A": null}`,
+		"enum no values":   `{"A": {"type": "enum"}}`,
+		"regex no pattern": `{"A": {"type": "regex"}}`,
+		"invalid regex":    `{"A": {"type": "regex", "pattern": "("}}`,
+		"invalid default":  `{"A": {"type": "int", "default": "x"}}`,
+	}
+
+	for name, schema := range schemas {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseSchema(strings.NewReader(schema)); err == nil {
+				t.Errorf("ParseSchema() succeeded, want an error")
+			}
+		})
+	}
+}
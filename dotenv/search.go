@@ -0,0 +1,75 @@
+package dotenv
+
+import (
+	"os"
+	"path"
+)
+
+// SearchFile returns the path of the first file matching one of names inside
+// directories, or an empty string if there is none. Directories that cannot
+// be read are skipped.
+func SearchFile(directories, names []string, recursive bool) string {
+	for _, dir := range directories {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, name := range names {
+			for _, file := range files {
+				if file.Name() == name && !file.IsDir() {
+					return path.Join(dir, name)
+				}
+			}
+		}
+
+		if recursive {
+			found := searchFileInSubdirs(dir, names)
+			if found != "" {
+				return found
+			}
+		}
+	}
+	return ""
+}
+
+// SearchFiles looks up each of names with SearchFile and returns the paths
+// that were found, in the order of names.
+func SearchFiles(directories, names []string, recursive bool) []string {
+	var found []string
+	for _, name := range names {
+		if file := SearchFile(directories, []string{name}, recursive); file != "" {
+			found = append(found, file)
+		}
+	}
+	return found
+}
+
+func searchFileInSubdirs(directory string, names []string) string {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return ""
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			subdir := path.Join(directory, entry.Name())
+			for _, name := range names {
+				subEntries, err := os.ReadDir(subdir)
+				if err != nil {
+					continue
+				}
+				for _, subEntry := range subEntries {
+					if subEntry.Name() == name && !subEntry.IsDir() {
+						return path.Join(subdir, name)
+					}
+				}
+			}
+			found := searchFileInSubdirs(subdir, names)
+			if found != "" {
+				return found
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,16 @@
+package dotenv
+
+import "regexp"
+
+// SecretPattern matches the names of variables that usually hold secrets,
+// such as API_KEY, DB_PASSWORD or GITHUB_TOKEN. It matches whole words of the
+// name, so MONKEY or KEYBOARD do not count. PWD only counts at the end of
+// a longer name such as DB_PWD, since the shell sets PWD to the working
+// directory.
+var SecretPattern = regexp.MustCompile(`(?i)(^|_)(SECRET|SECRETS|PASSWORD|PASSWD|PASS|TOKEN|KEY|APIKEY|CREDENTIAL|CREDENTIALS|PRIVATE|AUTH|CERT|DSN|SALT)(_|$)|_PWD$`)
+
+// IsSecret reports whether name looks like the name of a secret according to
+// SecretPattern.
+func IsSecret(name string) bool {
+	return SecretPattern.MatchString(name)
+}
@@ -0,0 +1,28 @@
+package dotenv
+
+import "testing"
+
+func TestIsSecret(t *testing.T) {
+	tests := map[string]bool{
+		"API_KEY":         true,
+		"DB_PASSWORD":     true,
+		"GITHUB_TOKEN":    true,
+		"secret":          true,
+		"AWS_SECRET_KEY":  true,
+		"SENTRY_DSN":      true,
+		"PRIVATE_KEY_PEM": true,
+		"MONKEY":          false,
+		"KEYBOARD_LAYOUT": false,
+		"DATABASE_URL":    false,
+		"PASSENGER":       false,
+		"DB_PWD":          true,
+		"PWD":             false,
+		"OLDPWD":          false,
+	}
+
+	for name, expected := range tests {
+		if result := IsSecret(name); result != expected {
+			t.Errorf("IsSecret(%q) = %v, want %v", name, result, expected)
+		}
+	}
+}
@@ -1,17 +1,19 @@
-package main
+package dotenv
 
 import (
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 )
 
+// IsShell reports whether $SHELL refers to the shell called name.
 func IsShell(name string) bool {
 	shell := os.Getenv("SHELL")
 	return strings.HasSuffix(shell, name)
 }
 
+// DetectShell guesses the shell the tool is running in. It returns an empty
+// string if the shell cannot be determined.
 func DetectShell() string {
 	shells := []string{"bash", "zsh", "fish", "powershell", "sh"}
 	for _, shell := range shells {
@@ -28,8 +30,10 @@ func DetectShell() string {
 	return ""
 }
 
+// TransformToShellSyntax renders a single variable as a line for the given
+// shell or output mode. It returns an empty string for unknown shells.
 func TransformToShellSyntax(variable Variable, shellName string) string {
-	value := strconv.Quote(variable.Value)
+	value := QuoteForShell(shellName, variable.Value)
 	switch shellName {
 	case "bash", "zsh", "sh":
 		return fmt.Sprintf("export %s=%s", variable.Name, value)
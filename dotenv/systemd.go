@@ -0,0 +1,76 @@
+package dotenv
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderSystemd renders a file for the EnvironmentFile= setting of systemd
+// units. Values are double-quoted, inside of which systemd only treats \ as
+// an escape for ", \, ` and $ and keeps line breaks.
+func renderSystemd(variables []Variable, _ RenderOptions) (string, error) {
+	var result strings.Builder
+	for _, variable := range variables {
+		if strings.ContainsRune(variable.Value, 0) {
+			return "", fmt.Errorf("the value of %s contains a NUL character, which systemd cannot represent", variable.Name)
+		}
+		fmt.Fprintf(&result, "%s=\"%s\"\n", variable.Name, escapeSystemdEnvironmentFile(variable.Value))
+	}
+	return result.String(), nil
+}
+
+// renderSystemdDropIn renders a drop-in unit file that sets the variables
+// with Environment= in the [Service] section.
+func renderSystemdDropIn(variables []Variable, _ RenderOptions) (string, error) {
+	var result strings.Builder
+	result.WriteString("[Service]\n")
+	for _, variable := range variables {
+		if strings.ContainsRune(variable.Value, 0) {
+			return "", fmt.Errorf("the value of %s contains a NUL character, which systemd cannot represent", variable.Name)
+		}
+		fmt.Fprintf(&result, "Environment=\"%s\"\n", escapeSystemdUnit(variable.Name+"="+variable.Value))
+	}
+	return result.String(), nil
+}
+
+// escapeSystemdEnvironmentFile escapes value for double quotes in an
+// environment file.
+func escapeSystemdEnvironmentFile(value string) string {
+	var result strings.Builder
+	for _, char := range value {
+		switch char {
+		case '"', '\\', '`', '$':
+			result.WriteByte('\\')
+		}
+		result.WriteRune(char)
+	}
+	return result.String()
+}
+
+// escapeSystemdUnit escapes value for double quotes in a unit file setting.
+// Unit files support C escape sequences, and % starts a specifier.
+func escapeSystemdUnit(value string) string {
+	var result strings.Builder
+	for _, char := range value {
+		switch char {
+		case '"', '\\':
+			result.WriteByte('\\')
+			result.WriteRune(char)
+		case '%':
+			result.WriteString("%%")
+		case '\n':
+			result.WriteString(`\n`)
+		case '\r':
+			result.WriteString(`\r`)
+		case '\t':
+			result.WriteString(`\t`)
+		default:
+			if char < 0x20 || char == 0x7f {
+				fmt.Fprintf(&result, `\x%02x`, char)
+			} else {
+				result.WriteRune(char)
+			}
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,43 @@
+package dotenv
+
+import "testing"
+
+var systemdVariables = []Variable{
+	{Name: "HOST", Value: "localhost"},
+	{Name: "SPECIAL", Value: "say \"hi\" \\ `cmd` $HOME 100%"},
+	{Name: "KEY", Value: "l1\nl2\n"},
+	{Name: "CONTROL", Value: "tab\tbell\a"},
+}
+
+func TestRenderSystemd(t *testing.T) {
+	output, err := Render(systemdVariables, "systemd")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := "HOST=\"localhost\"\n" +
+		"SPECIAL=\"say \\\"hi\\\" \\\\ \\`cmd\\` \\$HOME 100%\"\n" +
+		"KEY=\"l1\nl2\n\"\n" +
+		"CONTROL=\"tab\tbell\a\"\n"
+	if output != expected {
+		t.Errorf("Render() = %q, want %q", output, expected)
+	}
+
+	if _, err := Render([]Variable{{Name: "NUL", Value: "\x00"}}, "systemd"); err == nil {
+		t.Errorf("Render() with a NUL character succeeded, want an error")
+	}
+}
+
+func TestRenderSystemdDropIn(t *testing.T) {
+	output, err := Render(systemdVariables, "systemd-dropin")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := "[Service]\n" +
+		"Environment=\"HOST=localhost\"\n" +
+		"Environment=\"SPECIAL=say \\\"hi\\\" \\\\ `cmd` $HOME 100%%\"\n" +
+		"Environment=\"KEY=l1\\nl2\\n\"\n" +
+		"Environment=\"CONTROL=tab\\tbell\\x07\"\n"
+	if output != expected {
+		t.Errorf("Render() = %q, want %q", output, expected)
+	}
+}
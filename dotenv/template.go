@@ -0,0 +1,97 @@
+package dotenv
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode/utf16"
+)
+
+// TemplateFuncs are the functions available in templates parsed by
+// ParseTemplate:
+//
+//   - quote VALUE: VALUE single-quoted for sh, bash and zsh
+//   - shellquote SHELL VALUE: VALUE quoted for SHELL as in shell output
+//   - json VALUE: VALUE encoded as JSON, a quoted string for strings
+//   - base64 VALUE: VALUE encoded as standard base64
+//   - properties VALUE: VALUE escaped for the value of a Java .properties
+//     file
+//   - upper VALUE, lower VALUE: VALUE in upper or lower case
+//   - base PATH: the last element of PATH, such as the name of .File
+var TemplateFuncs = template.FuncMap{
+	"quote":      quotePOSIX,
+	"shellquote": QuoteForShell,
+	"json": func(value any) (string, error) {
+		encoded, err := marshalJSON(value)
+		return string(encoded), err
+	},
+	"base64": func(value string) string {
+		return base64.StdEncoding.EncodeToString([]byte(value))
+	},
+	"properties": escapeProperties,
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"base":       filepath.Base,
+}
+
+// ParseTemplate parses text as a text/template with TemplateFuncs, for use
+// as RenderOptions.Template. The template is executed with the variables,
+// a []Variable, so it usually ranges over them:
+//
+//	{{range .}}{{.Name}} = {{json .Value}}  # {{base .File}}:{{.Line}}
+//	{{end}}
+func ParseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(TemplateFuncs).Parse(text)
+}
+
+// renderTemplate executes options.Template with the variables.
+func renderTemplate(variables []Variable, options RenderOptions) (string, error) {
+	if options.Template == nil {
+		return "", errors.New("a template is required for the template format")
+	}
+
+	var result strings.Builder
+	if err := options.Template.Execute(&result, variables); err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// escapeProperties escapes value for the value of a .properties file as read
+// by java.util.Properties: backslashes, line breaks, separators, comment
+// characters and leading spaces are escaped, and characters outside of
+// printable ASCII are written as \uXXXX, since the file is read as ISO 8859-1.
+func escapeProperties(value string) string {
+	var result strings.Builder
+	leading := true
+	for _, char := range value {
+		if char != ' ' {
+			leading = false
+		}
+		switch {
+		case char == '\\':
+			result.WriteString(`\\`)
+		case char == '\n':
+			result.WriteString(`\n`)
+		case char == '\r':
+			result.WriteString(`\r`)
+		case char == '\t':
+			result.WriteString(`\t`)
+		case char == '\f':
+			result.WriteString(`\f`)
+		case char == ' ' && leading, strings.ContainsRune("=:#!", char):
+			result.WriteByte('\\')
+			result.WriteRune(char)
+		case char < 0x20 || char > 0x7e:
+			for _, unit := range utf16.Encode([]rune{char}) {
+				fmt.Fprintf(&result, `\u%04X`, unit)
+			}
+		default:
+			result.WriteRune(char)
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,130 @@
+package dotenv
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	variables := []Variable{
+		{Name: "Host", Value: "localhost", File: "config/.env", Line: 1},
+		{Name: "MOTD", Value: `it's "$5"`, File: "config/.env", Line: 2},
+		{Name: "Host", Value: "example.com", File: "config/.env.local", Line: 4},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"fields", `{{range .}}{{.Name}}={{.Value}} {{.File}}:{{.Line}};{{end}}`, `Host=example.com config/.env.local:4;MOTD=it's "$5" config/.env:2;`},
+		{"quote", `{{range .}}{{quote .Value}};{{end}}`, `'example.com';'it'\''s "$5"';`},
+		{"shellquote", `{{range .}}{{.Value | shellquote "fish"}};{{end}}`, `'example.com';'it\'s "$5"';`},
+		{"json", `{{range .}}{{json .Value}};{{end}}`, `"example.com";"it's \"$5\"";`},
+		{"base64", `{{range .}}{{base64 .Value}};{{end}}`, `ZXhhbXBsZS5jb20=;aXQncyAiJDUi;`},
+		{"properties", `{{range .}}{{properties .Value}};{{end}}`, `example.com;it's "$5";`},
+		{"case", `{{range .}}{{upper .Name}} {{lower .Name}};{{end}}`, `HOST host;MOTD motd;`},
+		{"base", `{{range .}}{{base .File}};{{end}}`, `.env.local;.env;`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(test.name, test.template)
+			if err != nil {
+				t.Fatalf("ParseTemplate() error = %v", err)
+			}
+			output, err := RenderWithOptions(variables, "template", RenderOptions{Template: tmpl})
+			if err != nil {
+				t.Fatalf("RenderWithOptions() error = %v", err)
+			}
+			if output != test.expected {
+				t.Errorf("RenderWithOptions() = %q, want %q", output, test.expected)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateErrors(t *testing.T) {
+	if _, err := ParseTemplate("bad", "{{range .}"); err == nil {
+		t.Errorf("ParseTemplate() with a syntax error succeeded")
+	}
+
+	if _, err := Render([]Variable{{Name: "A"}}, "template"); err == nil || !strings.Contains(err.Error(), "template is required") {
+		t.Errorf("Render() without a template error = %v", err)
+	}
+
+	tmpl, err := ParseTemplate("field", "{{range .}}{{.Missing}}{{end}}")
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+	if _, err := RenderWithOptions([]Variable{{Name: "A"}}, "template", RenderOptions{Template: tmpl}); err == nil {
+		t.Errorf("RenderWithOptions() with an unknown field succeeded")
+	}
+}
+
+// loadPropertiesValue decodes a value of a .properties file like
+// java.util.Properties does.
+func loadPropertiesValue(t *testing.T, escaped string) string {
+	t.Helper()
+	escaped = strings.TrimLeft(escaped, " \t\f")
+	var result strings.Builder
+	for i := 0; i < len(escaped); i++ {
+		if escaped[i] != '\\' {
+			result.WriteByte(escaped[i])
+			continue
+		}
+		i++
+		switch escaped[i] {
+		case 't':
+			result.WriteByte('\t')
+		case 'n':
+			result.WriteByte('\n')
+		case 'r':
+			result.WriteByte('\r')
+		case 'f':
+			result.WriteByte('\f')
+		case 'u':
+			unit, err := strconv.ParseUint(escaped[i+1:i+5], 16, 16)
+			if err != nil {
+				t.Fatalf("invalid escape in %q: %v", escaped, err)
+			}
+			// Surrogate pairs are not joined, which the tests do not need
+			result.WriteString(string(utf16.Decode([]uint16{uint16(unit)})))
+			i += 4
+		default:
+			result.WriteByte(escaped[i])
+		}
+	}
+	return result.String()
+}
+
+func TestEscapeProperties(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"plain value", "plain value"},
+		{"  leading spaces", `\ \ leading spaces`},
+		{`C:\path`, `C\:\\path`},
+		{"a=b#c!d", `a\=b\#c\!d`},
+		{"-----BEGIN-----\nabc\n", `-----BEGIN-----\nabc\n`},
+		{"tab\tfeed\f", `tab\tfeed\f`},
+		{"caf\u00e9 \u20ac", `caf\u00E9 \u20AC`},
+	}
+	for _, test := range tests {
+		escaped := escapeProperties(test.value)
+		if escaped != test.expected {
+			t.Errorf("escapeProperties(%q) = %q, want %q", test.value, escaped, test.expected)
+		}
+		if loaded := loadPropertiesValue(t, escaped); loaded != test.value {
+			t.Errorf("escapeProperties(%q) loads as %q", test.value, loaded)
+		}
+	}
+
+	// Characters outside of the Basic Multilingual Plane need surrogate
+	// pairs, which Java joins when reading the string
+	if escaped := escapeProperties("\U0001F600"); escaped != `\uD83D\uDE00` {
+		t.Errorf("escapeProperties() = %q, want a surrogate pair", escaped)
+	}
+}
@@ -0,0 +1,66 @@
+package dotenv
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// renderTOML renders a TOML document with one string per variable.
+// Multiline values become multi-line basic strings.
+func renderTOML(variables []Variable, _ RenderOptions) (string, error) {
+	var result strings.Builder
+	for _, variable := range variables {
+		// Variable names are always valid bare keys
+		fmt.Fprintf(&result, "%s = %s\n", variable.Name, tomlString(variable.Value))
+	}
+	return result.String(), nil
+}
+
+// tomlString returns value as a TOML basic string, or as a multi-line basic
+// string if it contains line feeds. Quotes and backslashes are always
+// escaped, so three quotes never appear in a row.
+func tomlString(value string) string {
+	multiline := strings.Contains(value, "\n")
+
+	var result strings.Builder
+	if multiline {
+		// A line feed directly after the opening delimiter is trimmed
+		result.WriteString("\"\"\"\n")
+	} else {
+		result.WriteByte('"')
+	}
+	for _, char := range value {
+		switch char {
+		case '"', '\\':
+			result.WriteByte('\\')
+			result.WriteRune(char)
+		case '\n':
+			if multiline {
+				result.WriteByte('\n')
+			} else {
+				result.WriteString(`\n`)
+			}
+		case '\t':
+			result.WriteByte('\t')
+		case '\r':
+			result.WriteString(`\r`)
+		case '\b':
+			result.WriteString(`\b`)
+		case '\f':
+			result.WriteString(`\f`)
+		default:
+			if unicode.IsControl(char) {
+				fmt.Fprintf(&result, `\u%04X`, char)
+			} else {
+				result.WriteRune(char)
+			}
+		}
+	}
+	if multiline {
+		result.WriteString(`"""`)
+	} else {
+		result.WriteByte('"')
+	}
+	return result.String()
+}
@@ -0,0 +1,166 @@
+package dotenv
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SnapshotVariable holds the values that loaded variables had before they
+// were loaded, written by Snapshot and read by RenderUnload.
+const SnapshotVariable = "DOTENV_PREVIOUS"
+
+// snapshotEntry is the previous value of one variable. Value is nil if the
+// variable was not set.
+type snapshotEntry struct {
+	Name  string  `json:"name"`
+	Value *string `json:"value,omitempty"`
+}
+
+// TransformToUnsetSyntax renders a line that removes the variable name in
+// the given shell. It returns an empty string for unknown shells and output
+// modes that are not shells.
+func TransformToUnsetSyntax(name, shellName string) string {
+	switch shellName {
+	case "bash", "zsh", "sh":
+		return "unset " + name
+	case "fish":
+		return "set -e " + name
+	case "cmd":
+		return fmt.Sprintf("set %s=", name)
+	case "powershell":
+		return fmt.Sprintf("Remove-Item Env:%s -ErrorAction SilentlyContinue", name)
+	default:
+		return ""
+	}
+}
+
+// Snapshot returns the SnapshotVariable to load together with variables, so
+// that RenderUnload can restore the values they have in environ, in the
+// "NAME=value" form of os.Environ. If environ already holds a snapshot, the
+// values recorded there are kept and only variables it does not know are
+// added, so unloading reverts every load since the first. The value is
+// base64-encoded JSON, which needs no quoting in any shell.
+func Snapshot(variables []Variable, environ []string, shellName string) Variable {
+	foldCase := shellName == "cmd" || shellName == "powershell"
+	previous := environValues(environ, foldCase)
+
+	// An invalid snapshot is replaced by a new one
+	entries, _ := decodeSnapshot(previous[environKey(SnapshotVariable, foldCase)])
+	recorded := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		recorded[environKey(entry.Name, foldCase)] = true
+	}
+	for _, variable := range Merge(variables) {
+		name := environKey(variable.Name, foldCase)
+		if recorded[name] || name == environKey(SnapshotVariable, foldCase) {
+			continue
+		}
+		recorded[name] = true
+		entry := snapshotEntry{Name: variable.Name}
+		if value, ok := previous[name]; ok {
+			entry.Value = &value
+		}
+		entries = append(entries, entry)
+	}
+
+	// Encoding strings and pointers to strings cannot fail
+	data, _ := json.Marshal(entries)
+	return Variable{Name: SnapshotVariable, Value: base64.StdEncoding.EncodeToString(data)}
+}
+
+// RenderUnload renders a script for the given shell that reverts loading
+// variables. If environ, in the "NAME=value" form of os.Environ, holds a
+// snapshot written by Snapshot, the script restores the variables recorded
+// in it and removes the snapshot; variables is not used then. Otherwise
+// every variable that is set in environ is set back to that value and the
+// others are unset, which only reverts anything while environ still holds
+// the previous values. If every variable already has its value in environ,
+// the variables look loaded without a snapshot and an error is returned
+// instead of a script that changes nothing. Names are compared
+// case-insensitively for cmd and powershell, like Windows does.
+func RenderUnload(variables []Variable, environ []string, shellName string) (string, error) {
+	if TransformToUnsetSyntax("NAME", shellName) == "" {
+		return "", fmt.Errorf("cannot unload variables in %q, only in shells", shellName)
+	}
+	foldCase := shellName == "cmd" || shellName == "powershell"
+	previous := environValues(environ, foldCase)
+
+	if snapshot, ok := previous[environKey(SnapshotVariable, foldCase)]; ok {
+		entries, err := decodeSnapshot(snapshot)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s: %w", SnapshotVariable, err)
+		}
+		lines := make([]string, 0, len(entries)+1)
+		for _, entry := range entries {
+			lines = append(lines, restoreLine(entry.Name, entry.Value, shellName))
+		}
+		lines = append(lines, TransformToUnsetSyntax(SnapshotVariable, shellName))
+		return strings.Join(lines, "\n"), nil
+	}
+
+	variables = Merge(variables)
+	loaded := len(variables) > 0
+	lines := make([]string, 0, len(variables))
+	for _, variable := range variables {
+		value, ok := previous[environKey(variable.Name, foldCase)]
+		if !ok || value != variable.Value {
+			loaded = false
+		}
+		if ok {
+			lines = append(lines, restoreLine(variable.Name, &value, shellName))
+		} else {
+			lines = append(lines, TransformToUnsetSyntax(variable.Name, shellName))
+		}
+	}
+	if loaded {
+		return "", errors.New("the variables are already set to the values of the file and there is no " + SnapshotVariable + " to restore the previous values from, render the script before loading the file")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// restoreLine renders a line that sets name back to value, or unsets it if
+// value is nil.
+func restoreLine(name string, value *string, shellName string) string {
+	if value == nil {
+		return TransformToUnsetSyntax(name, shellName)
+	}
+	return TransformToShellSyntax(Variable{Name: name, Value: *value}, shellName)
+}
+
+// environValues maps the names in environ to their values, with upper case
+// names if foldCase is set.
+func environValues(environ []string, foldCase bool) map[string]string {
+	values := make(map[string]string, len(environ))
+	for _, variable := range EnvironVariables(environ) {
+		values[environKey(variable.Name, foldCase)] = variable.Value
+	}
+	return values
+}
+
+// environKey returns name as it is compared, in upper case if foldCase is set.
+func environKey(name string, foldCase bool) string {
+	if foldCase {
+		return strings.ToUpper(name)
+	}
+	return name
+}
+
+// decodeSnapshot decodes the value of SnapshotVariable. An empty value is an
+// empty snapshot.
+func decodeSnapshot(value string) ([]snapshotEntry, error) {
+	if value == "" {
+		return nil, nil
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var entries []snapshotEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,110 @@
+package dotenv
+
+import "testing"
+
+func TestRenderUnload(t *testing.T) {
+	variables := []Variable{
+		{Name: "HOST", Value: "localhost"},
+		{Name: "EDITOR", Value: "nano"},
+		{Name: "HOST", Value: "example.com"},
+	}
+	environ := []string{"EDITOR=vim -u 'x'", "HOME=/root"}
+
+	tests := []struct {
+		shell    string
+		expected string
+	}{
+		{"bash", "unset HOST\nexport EDITOR='vim -u '\\''x'\\'''"},
+		{"zsh", "unset HOST\nexport EDITOR='vim -u '\\''x'\\'''"},
+		{"fish", "set -e HOST\nset -x EDITOR 'vim -u \\'x\\''"},
+		{"powershell", "Remove-Item Env:HOST -ErrorAction SilentlyContinue\n$env:EDITOR='vim -u ''x'''"},
+		{"cmd", "set HOST=\nset EDITOR=vim -u 'x'"},
+	}
+	for _, test := range tests {
+		t.Run(test.shell, func(t *testing.T) {
+			output, err := RenderUnload(variables, environ, test.shell)
+			if err != nil {
+				t.Fatalf("RenderUnload() error = %v", err)
+			}
+			if output != test.expected {
+				t.Errorf("RenderUnload() = %q, want %q", output, test.expected)
+			}
+		})
+	}
+}
+
+func TestRenderUnloadWindowsCase(t *testing.T) {
+	output, err := RenderUnload([]Variable{{Name: "Path", Value: "C:\\bin"}}, []string{"PATH=C:\\Windows"}, "cmd")
+	if err != nil {
+		t.Fatalf("RenderUnload() error = %v", err)
+	}
+	if expected := "set Path=C:\\Windows"; output != expected {
+		t.Errorf("RenderUnload() = %q, want %q", output, expected)
+	}
+
+	output, _ = RenderUnload([]Variable{{Name: "Path", Value: "/bin"}}, []string{"PATH=/usr/bin"}, "bash")
+	if expected := "unset Path"; output != expected {
+		t.Errorf("RenderUnload() = %q, want %q", output, expected)
+	}
+}
+
+func TestRenderUnloadUnsupported(t *testing.T) {
+	for _, format := range []string{"json", "value", "none", ""} {
+		if _, err := RenderUnload([]Variable{{Name: "A"}}, nil, format); err == nil {
+			t.Errorf("RenderUnload() for %q succeeded, want an error", format)
+		}
+	}
+}
+
+func TestRenderUnloadSnapshot(t *testing.T) {
+	variables := []Variable{{Name: "HOST", Value: "localhost"}, {Name: "EDITOR", Value: "nano"}}
+	snapshot := Snapshot(variables, []string{"EDITOR=vim", "HOME=/root"}, "bash")
+	if snapshot.Name != SnapshotVariable {
+		t.Fatalf("Snapshot() name = %q, want %q", snapshot.Name, SnapshotVariable)
+	}
+
+	// Loading again keeps the values from before the first load
+	loaded := []string{"EDITOR=nano", "HOST=localhost", "HOME=/root", snapshot.Name + "=" + snapshot.Value}
+	again := Snapshot([]Variable{{Name: "HOST", Value: "example.com"}, {Name: "PORT", Value: "80"}}, loaded, "bash")
+	loaded = []string{"EDITOR=nano", "HOST=example.com", "PORT=80", "HOME=/root", again.Name + "=" + again.Value}
+
+	output, err := RenderUnload(nil, loaded, "bash")
+	if err != nil {
+		t.Fatalf("RenderUnload() error = %v", err)
+	}
+	if expected := "unset HOST\nexport EDITOR='vim'\nunset PORT\nunset DOTENV_PREVIOUS"; output != expected {
+		t.Errorf("RenderUnload() = %q, want %q", output, expected)
+	}
+}
+
+func TestRenderUnloadSnapshotWindowsCase(t *testing.T) {
+	snapshot := Snapshot([]Variable{{Name: "Path", Value: "C:\\bin"}}, []string{"PATH=C:\\Windows"}, "powershell")
+	output, err := RenderUnload(nil, []string{"PATH=C:\\bin", "dotenv_previous=" + snapshot.Value}, "powershell")
+	if err != nil {
+		t.Fatalf("RenderUnload() error = %v", err)
+	}
+	if expected := "$env:Path='C:\\Windows'\nRemove-Item Env:DOTENV_PREVIOUS -ErrorAction SilentlyContinue"; output != expected {
+		t.Errorf("RenderUnload() = %q, want %q", output, expected)
+	}
+}
+
+func TestRenderUnloadInvalidSnapshot(t *testing.T) {
+	if _, err := RenderUnload(nil, []string{SnapshotVariable + "=not base64"}, "bash"); err == nil {
+		t.Error("RenderUnload() succeeded, want an error")
+	}
+}
+
+func TestRenderUnloadAlreadyLoaded(t *testing.T) {
+	variables := []Variable{{Name: "HOST", Value: "localhost"}, {Name: "PORT", Value: "80"}}
+	if _, err := RenderUnload(variables, []string{"HOST=localhost", "PORT=80"}, "bash"); err == nil {
+		t.Error("RenderUnload() succeeded for loaded variables, want an error")
+	}
+
+	output, err := RenderUnload(variables, []string{"HOST=localhost", "PORT=8080"}, "bash")
+	if err != nil {
+		t.Fatalf("RenderUnload() error = %v", err)
+	}
+	if expected := "export HOST='localhost'\nexport PORT='8080'"; output != expected {
+		t.Errorf("RenderUnload() = %q, want %q", output, expected)
+	}
+}
@@ -0,0 +1,277 @@
+package dotenv
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldError is a struct field that Unmarshal could not set.
+type FieldError struct {
+	// Name is the variable the field is read from.
+	Name string
+	// Field is the path of the field, such as "Config.Database.Port".
+	Field string
+	// File and Line locate the definition of the variable, both empty if
+	// the value came from a default or the variable is not defined.
+	File string
+	Line int
+	Err  error
+}
+
+// Error formats the error as file:line: NAME (Field): message.
+func (e *FieldError) Error() string {
+	message := fmt.Sprintf("%s (%s): %v", e.Name, e.Field, e.Err)
+	if e.File == "" {
+		return message
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, message)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors is returned by Unmarshal and holds an error for every field
+// that could not be set.
+type FieldErrors []*FieldError
+
+// Error lists all errors, one per line.
+func (l FieldErrors) Error() string {
+	messages := make([]string, len(l))
+	for i, err := range l {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the errors of the list, so that errors.As finds the first
+// FieldError.
+func (l FieldErrors) Unwrap() []error {
+	errs := make([]error, len(l))
+	for i, err := range l {
+		errs[i] = err
+	}
+	return errs
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Unmarshal sets the fields of the struct v points to from variables. A field
+// is read from the variable named in its env tag; the last definition of a
+// variable counts:
+//
+//	type Config struct {
+//		Port     int           `env:"PORT" default:"8080"`
+//		Database string        `env:"DATABASE_URL,required"`
+//		Timeout  time.Duration `env:"TIMEOUT"`
+//		Hosts    []string      `env:"HOSTS"`
+//		Limits   map[string]int `env:"LIMITS"`
+//	}
+//
+// If a variable is not defined, the default tag is used instead, and fields
+// marked required without a default are an error. Fields without a value or
+// default are left unchanged.
+//
+// Supported types are strings, booleans (see the bool type of Schema),
+// integers, floats, time.Duration, types implementing
+// encoding.TextUnmarshaler such as time.Time (RFC 3339), pointers to these,
+// slices of comma-separated elements and maps of comma-separated key:value
+// pairs. Struct fields without an env tag are filled recursively.
+//
+// Unmarshal sets every field it can and returns FieldErrors listing all
+// fields it could not set.
+func Unmarshal(variables []Variable, v any) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return errors.New("dotenv: Unmarshal needs a non-nil pointer to a struct")
+	}
+
+	defined := make(map[string]Variable, len(variables))
+	for _, variable := range variables {
+		defined[variable.Name] = variable
+	}
+
+	var errs FieldErrors
+	unmarshalStruct(target.Elem(), target.Elem().Type().Name(), defined, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// unmarshalStruct sets the fields of the struct value, whose path is path.
+func unmarshalStruct(value reflect.Value, path string, defined map[string]Variable, errs *FieldErrors) {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		fieldPath := path + "." + field.Name
+
+		tag, tagged := field.Tag.Lookup("env")
+		if !tagged {
+			if field.Type.Kind() == reflect.Struct && !reflect.PointerTo(field.Type).Implements(textUnmarshalerType) {
+				unmarshalStruct(value.Field(i), fieldPath, defined, errs)
+			}
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldErr := &FieldError{Name: name, Field: fieldPath}
+		variable, exists := defined[name]
+		raw := variable.Value
+		if exists {
+			fieldErr.File, fieldErr.Line = variable.File, variable.Line
+		} else if defaultValue, ok := field.Tag.Lookup("default"); ok {
+			raw = defaultValue
+		} else {
+			if hasOption(options, "required") {
+				fieldErr.Err = errors.New("required variable is not set")
+				*errs = append(*errs, fieldErr)
+			}
+			continue
+		}
+
+		if err := setValue(value.Field(i), raw); err != nil {
+			fieldErr.Err = err
+			*errs = append(*errs, fieldErr)
+		}
+	}
+}
+
+// hasOption reports whether the comma-separated options contain option.
+func hasOption(options, option string) bool {
+	for _, o := range strings.Split(options, ",") {
+		if strings.TrimSpace(o) == option {
+			return true
+		}
+	}
+	return false
+}
+
+// setValue converts raw to the type of value and stores it. value must be
+// settable.
+func setValue(value reflect.Value, raw string) error {
+	if value.Kind() == reflect.Pointer {
+		target := reflect.New(value.Type().Elem())
+		if err := setValue(target.Elem(), raw); err != nil {
+			return err
+		}
+		value.Set(target)
+		return nil
+	}
+
+	if unmarshaler, ok := value.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(raw))
+	}
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration such as 30s or 1h30m", raw)
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		b, err := parseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return numberError(raw, value.Type(), err)
+		}
+		value.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(raw, 10, value.Type().Bits())
+		if err != nil {
+			return numberError(raw, value.Type(), err)
+		}
+		value.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return numberError(raw, value.Type(), err)
+		}
+		value.SetFloat(f)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			value.SetBytes([]byte(raw))
+			return nil
+		}
+		parts := splitList(raw)
+		slice := reflect.MakeSlice(value.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := setValue(slice.Index(i), part); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		value.Set(slice)
+	case reflect.Map:
+		mapType := value.Type()
+		m := reflect.MakeMap(mapType)
+		for _, part := range splitList(raw) {
+			rawKey, rawValue, found := strings.Cut(part, ":")
+			if !found {
+				return fmt.Errorf("%q is not a key:value pair", part)
+			}
+			key := reflect.New(mapType.Key()).Elem()
+			if err := setValue(key, strings.TrimSpace(rawKey)); err != nil {
+				return fmt.Errorf("key %q: %w", rawKey, err)
+			}
+			element := reflect.New(mapType.Elem()).Elem()
+			if err := setValue(element, strings.TrimSpace(rawValue)); err != nil {
+				return fmt.Errorf("value of %q: %w", rawKey, err)
+			}
+			m.SetMapIndex(key, element)
+		}
+		value.Set(m)
+	default:
+		return fmt.Errorf("unsupported type %s", value.Type())
+	}
+	return nil
+}
+
+// numberError describes why raw could not be parsed as a number of type t.
+func numberError(raw string, t reflect.Type, err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return fmt.Errorf("%q is out of range for %s", raw, t)
+	}
+	return fmt.Errorf("%q is not a valid %s", raw, t)
+}
+
+// splitList splits a comma-separated list and trims its elements. An empty
+// string is an empty list.
+func splitList(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+	return parts
+}
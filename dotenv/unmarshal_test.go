@@ -0,0 +1,131 @@
+package dotenv
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testDatabase struct {
+	URL      string `env:"DATABASE_URL,required"`
+	MaxConns uint16 `env:"DATABASE_MAX_CONNS" default:"10"`
+}
+
+type testConfig struct {
+	Name     string            `env:"NAME"`
+	Port     int               `env:"PORT" default:"8080"`
+	Debug    bool              `env:"DEBUG"`
+	Ratio    float64           `env:"RATIO"`
+	Timeout  time.Duration     `env:"TIMEOUT"`
+	Started  time.Time         `env:"STARTED"`
+	Hosts    []string          `env:"HOSTS"`
+	Ports    []int             `env:"PORTS"`
+	Limits   map[string]int    `env:"LIMITS"`
+	IP       net.IP            `env:"IP"`
+	Optional *int              `env:"OPTIONAL"`
+	Missing  *int              `env:"MISSING"`
+	Labels   map[string]string `env:"LABELS"`
+	Ignored  string            `env:"-"`
+	Untagged string
+	Database testDatabase
+	hidden   string
+}
+
+func TestUnmarshal(t *testing.T) {
+	variables := []Variable{
+		{Name: "NAME", Value: "first"},
+		{Name: "NAME", Value: "app"},
+		{Name: "DEBUG", Value: "yes"},
+		{Name: "RATIO", Value: "0.75"},
+		{Name: "TIMEOUT", Value: "1m30s"},
+		{Name: "STARTED", Value: "2024-05-01T12:00:00Z"},
+		{Name: "HOSTS", Value: "a.example, b.example"},
+		{Name: "PORTS", Value: "80,443"},
+		{Name: "LIMITS", Value: "cpu:2, memory:512"},
+		{Name: "IP", Value: "10.0.0.1"},
+		{Name: "OPTIONAL", Value: "7"},
+		{Name: "LABELS", Value: ""},
+		{Name: "Untagged", Value: "x"},
+		{Name: "DATABASE_URL", Value: "postgres://localhost/app"},
+	}
+
+	config := testConfig{Ignored: "kept", Untagged: "kept"}
+	if err := Unmarshal(variables, &config); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	optional := 7
+	expected := testConfig{
+		Name:     "app",
+		Port:     8080,
+		Debug:    true,
+		Ratio:    0.75,
+		Timeout:  90 * time.Second,
+		Started:  time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Hosts:    []string{"a.example", "b.example"},
+		Ports:    []int{80, 443},
+		Limits:   map[string]int{"cpu": 2, "memory": 512},
+		IP:       net.ParseIP("10.0.0.1"),
+		Optional: &optional,
+		Labels:   map[string]string{},
+		Ignored:  "kept",
+		Untagged: "kept",
+		Database: testDatabase{URL: "postgres://localhost/app", MaxConns: 10},
+	}
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("Unmarshal() = %+v, want %+v", config, expected)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	variables := []Variable{
+		{Name: "PORT", Value: "http", File: ".env", Line: 1},
+		{Name: "DEBUG", Value: "maybe", File: ".env", Line: 2},
+		{Name: "PORTS", Value: "80,x", File: ".env", Line: 3},
+		{Name: "LIMITS", Value: "cpu", File: ".env", Line: 4},
+		{Name: "DATABASE_MAX_CONNS", Value: "70000", File: ".env", Line: 5},
+		{Name: "NAME", Value: "still set", File: ".env", Line: 6},
+	}
+
+	var config testConfig
+	err := Unmarshal(variables, &config)
+
+	var errs FieldErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Unmarshal() error = %v, want FieldErrors", err)
+	}
+	expected := []string{
+		`.env:1: PORT (testConfig.Port): "http" is not a valid int`,
+		`.env:2: DEBUG (testConfig.Debug): "maybe" is not a boolean`,
+		`.env:3: PORTS (testConfig.Ports): element 1: "x" is not a valid int`,
+		`.env:4: LIMITS (testConfig.Limits): "cpu" is not a key:value pair`,
+		`DATABASE_URL (testConfig.Database.URL): required variable is not set`,
+		`.env:5: DATABASE_MAX_CONNS (testConfig.Database.MaxConns): "70000" is out of range for uint16`,
+	}
+	if messages := strings.Split(err.Error(), "\n"); !reflect.DeepEqual(messages, expected) {
+		t.Errorf("Unmarshal() error =\n%s\nwant\n%s", err, strings.Join(expected, "\n"))
+	}
+	if config.Name != "still set" {
+		t.Errorf("Unmarshal() did not set valid fields, Name = %q", config.Name)
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Name != "PORT" {
+		t.Errorf("errors.As() did not find the first FieldError: %v", fieldErr)
+	}
+}
+
+func TestUnmarshalInvalidTarget(t *testing.T) {
+	var config testConfig
+	var nilConfig *testConfig
+	targets := []any{nil, config, nilConfig, new(int)}
+
+	for _, target := range targets {
+		if err := Unmarshal(nil, target); err == nil {
+			t.Errorf("Unmarshal(%T) succeeded, want an error", target)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package dotenv
+
+// MismatchKind classifies a Mismatch.
+type MismatchKind int
+
+const (
+	// Missing is reported for a key of the example that is not defined.
+	Missing MismatchKind = iota + 1
+	// Empty is reported for a key of the example whose value is empty.
+	Empty
+	// Undocumented is reported for a defined key that the example does not
+	// list. It is a warning rather than an error.
+	Undocumented
+)
+
+// String returns "missing", "empty" or "undocumented".
+func (k MismatchKind) String() string {
+	switch k {
+	case Missing:
+		return "missing"
+	case Empty:
+		return "empty"
+	case Undocumented:
+		return "undocumented"
+	default:
+		return "unknown"
+	}
+}
+
+// Mismatch is a difference between an example file and the variables that
+// are actually defined.
+type Mismatch struct {
+	Name string
+	Kind MismatchKind
+	// Variable is the definition in the example for Missing and the actual
+	// definition otherwise.
+	Variable Variable
+}
+
+// Verify checks variables against example, the variables of a template such
+// as .env.example. Every key of the example is required and must have a
+// non-empty value. Keys missing from the example are reported as
+// Undocumented. Keys of the example come first, in the order of the example,
+// followed by undocumented keys in the order they are defined.
+func Verify(example, variables []Variable) []Mismatch {
+	example = Merge(example)
+	variables = Merge(variables)
+
+	defined := make(map[string]Variable, len(variables))
+	for _, variable := range variables {
+		defined[variable.Name] = variable
+	}
+	documented := make(map[string]bool, len(example))
+
+	var mismatches []Mismatch
+	for _, expected := range example {
+		documented[expected.Name] = true
+		variable, exists := defined[expected.Name]
+		switch {
+		case !exists:
+			mismatches = append(mismatches, Mismatch{Name: expected.Name, Kind: Missing, Variable: expected})
+		case variable.Value == "":
+			mismatches = append(mismatches, Mismatch{Name: expected.Name, Kind: Empty, Variable: variable})
+		}
+	}
+	for _, variable := range variables {
+		if !documented[variable.Name] {
+			mismatches = append(mismatches, Mismatch{Name: variable.Name, Kind: Undocumented, Variable: variable})
+		}
+	}
+	return mismatches
+}
@@ -0,0 +1,37 @@
+package dotenv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	example := []Variable{
+		{Name: "DATABASE_URL", Value: "postgres://localhost/app", File: ".env.example", Line: 1},
+		{Name: "SECRET_KEY", Value: "", File: ".env.example", Line: 2},
+		{Name: "PORT", Value: "3000", File: ".env.example", Line: 3},
+		{Name: "API_TOKEN", Value: "", File: ".env.example", Line: 4},
+	}
+	variables := []Variable{
+		{Name: "EXTRA", Value: "1", File: ".env", Line: 1},
+		{Name: "SECRET_KEY", Value: "", File: ".env", Line: 2},
+		{Name: "PORT", Value: "", File: ".env", Line: 3},
+		{Name: "PORT", Value: "8080", File: ".env.local", Line: 1},
+		{Name: "DEBUG", Value: "", File: ".env", Line: 4},
+		{Name: "API_TOKEN", Value: "x", File: ".env", Line: 5},
+	}
+
+	expected := []Mismatch{
+		{Name: "DATABASE_URL", Kind: Missing, Variable: example[0]},
+		{Name: "SECRET_KEY", Kind: Empty, Variable: variables[1]},
+		{Name: "EXTRA", Kind: Undocumented, Variable: variables[0]},
+		{Name: "DEBUG", Kind: Undocumented, Variable: variables[4]},
+	}
+
+	if mismatches := Verify(example, variables); !reflect.DeepEqual(mismatches, expected) {
+		t.Errorf("Verify() = %v, want %v", mismatches, expected)
+	}
+	if mismatches := Verify(example, example[:1]); len(mismatches) != 3 {
+		t.Errorf("Verify() = %v, want 3 mismatches", mismatches)
+	}
+}
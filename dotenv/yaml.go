@@ -0,0 +1,101 @@
+package dotenv
+
+import (
+	"regexp"
+	"strings"
+)
+
+// yamlPlainRegex matches values that YAML reads back as the same string
+// without quotes: words starting with a letter or slash, separated by single
+// spaces.
+var yamlPlainRegex = regexp.MustCompile(`^[A-Za-z/][A-Za-z0-9_./@%+:=,-]*( [A-Za-z0-9_./@%+:=,-]+)*$`)
+
+// yamlReservedWords are read as booleans or null by YAML 1.1 or 1.2 parsers
+// when they are not quoted.
+var yamlReservedWords = map[string]bool{
+	"y": true, "n": true, "yes": true, "no": true, "on": true, "off": true,
+	"true": true, "false": true, "null": true,
+}
+
+// renderYAML renders a YAML mapping of names to string values. Multiline
+// values become literal block scalars.
+func renderYAML(variables []Variable, _ RenderOptions) (string, error) {
+	if len(variables) == 0 {
+		return "{}\n", nil
+	}
+
+	var result strings.Builder
+	for _, variable := range variables {
+		writeYAMLEntry(&result, "", variable.Name, variable.Value)
+	}
+	return result.String(), nil
+}
+
+// writeYAMLEntry writes "key: value" indented by indent.
+func writeYAMLEntry(result *strings.Builder, indent, key, value string) {
+	result.WriteString(indent)
+	result.WriteString(yamlScalar(key))
+	result.WriteByte(':')
+	if block, ok := yamlBlock(value, indent+"  "); ok {
+		result.WriteString(" ")
+		result.WriteString(block)
+		return
+	}
+	result.WriteByte(' ')
+	result.WriteString(yamlScalar(value))
+	result.WriteByte('\n')
+}
+
+// yamlScalar returns value as a plain scalar if YAML reads that back as the
+// same string, and as a double-quoted scalar otherwise.
+func yamlScalar(value string) string {
+	if yamlPlainRegex.MatchString(value) && !yamlReservedWords[strings.ToLower(value)] &&
+		!strings.Contains(value, ": ") && !strings.HasSuffix(value, ":") {
+		return value
+	}
+	// JSON strings are valid double-quoted YAML scalars
+	quoted, _ := marshalJSON(value)
+	return string(quoted)
+}
+
+// yamlBlock returns a literal block scalar with its header for a multiline
+// value, including the final line break, with the content indented by
+// indent, which is two spaces deeper than its key. It reports false if value
+// is not multiline or contains characters that cannot appear in a block
+// scalar.
+func yamlBlock(value, indent string) (string, bool) {
+	if !strings.Contains(value, "\n") || hasControl(value, "\t\n") || strings.ContainsRune(value, '\uFEFF') {
+		return "", false
+	}
+
+	content := strings.TrimRight(value, "\n")
+	if content == "" {
+		return "", false
+	}
+	var header strings.Builder
+	header.WriteByte('|')
+	if strings.HasPrefix(content, " ") || strings.HasPrefix(content, "\n") {
+		// The indentation cannot be detected from the first line
+		header.WriteByte('2')
+	}
+	switch trailing := len(value) - len(content); {
+	case trailing == 0:
+		header.WriteByte('-')
+	case trailing > 1:
+		header.WriteByte('+')
+	}
+	header.WriteByte('\n')
+
+	lines := strings.Split(value, "\n")
+	if strings.HasSuffix(value, "\n") {
+		lines = lines[:len(lines)-1]
+	}
+	for _, line := range lines {
+		if line != "" {
+			header.WriteString(indent)
+		}
+		header.WriteString(line)
+		header.WriteByte('\n')
+	}
+	return header.String(), true
+}
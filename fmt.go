@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MeroFuruya/dotenv/dotenv"
+)
+
+// ExportFlag is a flag.Value selecting a dotenv.ExportMode.
+type ExportFlag dotenv.ExportMode
+
+var exportModes = []string{"keep", "always", "never"}
+
+// String is an implementation of the flag.Value interface
+func (e *ExportFlag) String() string {
+	return exportModes[*e]
+}
+
+// Set is an implementation of the flag.Value interface
+func (e *ExportFlag) Set(value string) error {
+	for i, mode := range exportModes {
+		if mode == value {
+			*e = ExportFlag(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown export mode %q (available: %s)", value, strings.Join(exportModes, ", "))
+}
+
+// FmtCommand implements `dotenv fmt [options] [file...]`. It prints the
+// canonical form of the given files, or of the dotenv file found by the
+// search flags. With -w the files are rewritten; with -check a diff is
+// printed for every file that is not formatted and 1 is returned.
+func FmtCommand(args []string) int {
+	fs := flag.NewFlagSet("fmt", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dotenv fmt [options] [file...]")
+		fs.PrintDefaults()
+	}
+	var search SearchFlags
+	search.Register(fs)
+	var options dotenv.FormatOptions
+	fs.BoolVar(&options.Sort, "sort", false, "Sort variables by name, keeping comments directly above a variable with it (default: false)")
+	fs.Var((*ExportFlag)(&options.Export), "export", "How to write export prefixes: keep, always or never")
+	write := fs.Bool("w", false, "Write the result back to the file instead of printing it (default: false)")
+	check := fs.Bool("check", false, "Print a diff and exit with status 1 if a file is not formatted (default: false)")
+	fs.Parse(args)
+
+	dotenvFiles := fs.Args()
+	if len(dotenvFiles) == 0 {
+		var ok bool
+		if dotenvFiles, ok = search.Files(); !ok {
+			return 1
+		}
+	}
+
+	status := 0
+	for _, dotenvFile := range dotenvFiles {
+		content, err := os.ReadFile(dotenvFile)
+		if err != nil {
+			Error("Error reading dotenv file:", err)
+			status = 1
+			continue
+		}
+		doc, err := dotenv.ParseDocument(bytes.NewReader(content))
+		if err == nil {
+			doc, err = dotenv.Format(doc, options)
+		}
+		if err != nil {
+			reportFormatError(dotenvFile, err)
+			status = 1
+			continue
+		}
+		formatted := doc.String()
+
+		switch {
+		case *check:
+			if formatted != string(content) {
+				fmt.Print(UnifiedDiff(dotenvFile, dotenvFile+" (formatted)", string(content), formatted))
+				status = 1
+			}
+		case *write:
+			if formatted != string(content) {
+				if err := WriteFileAtomic(dotenvFile, []byte(formatted)); err != nil {
+					Error("Error writing dotenv file:", err)
+					status = 1
+				}
+			}
+		default:
+			fmt.Print(formatted)
+		}
+	}
+	return status
+}
+
+// reportFormatError reports an error from dotenv.Format for dotenvFile.
+func reportFormatError(dotenvFile string, err error) {
+	var errs dotenv.ErrorList
+	if errors.As(err, &errs) {
+		for _, parseErr := range errs {
+			parseErr.File = dotenvFile
+		}
+		ReportLoadError(errs)
+		return
+	}
+	Error("Error formatting", dotenvFile+":", err)
+}
+
+// UnifiedDiff returns the differences between the lines of a and b in
+// unified diff format with three lines of context, or an empty string if
+// they are equal.
+func UnifiedDiff(nameA, nameB, a, b string) string {
+	linesA := strings.SplitAfter(a, "\n")
+	linesB := strings.SplitAfter(b, "\n")
+	if linesA[len(linesA)-1] == "" {
+		linesA = linesA[:len(linesA)-1]
+	}
+	if linesB[len(linesB)-1] == "" {
+		linesB = linesB[:len(linesB)-1]
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of
+	// linesA[i:] and linesB[j:]
+	lcs := make([][]int, len(linesA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(linesB)+1)
+	}
+	for i := len(linesA) - 1; i >= 0; i-- {
+		for j := len(linesB) - 1; j >= 0; j-- {
+			if linesA[i] == linesB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// Walk the table to get the edit script, one operation per line
+	type edit struct {
+		op   byte
+		line string
+	}
+	var edits []edit
+	i, j := 0, 0
+	for i < len(linesA) || j < len(linesB) {
+		switch {
+		case i < len(linesA) && j < len(linesB) && linesA[i] == linesB[j]:
+			edits = append(edits, edit{' ', linesA[i]})
+			i++
+			j++
+		case i < len(linesA) && (j == len(linesB) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{'-', linesA[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', linesB[j]})
+			j++
+		}
+	}
+
+	const context = 3
+	var result strings.Builder
+	for start := 0; start < len(edits); {
+		if edits[start].op == ' ' {
+			start++
+			continue
+		}
+
+		// Extend the hunk while changes are at most 2*context lines apart
+		end := start
+		for k := start; k < len(edits); k++ {
+			if edits[k].op != ' ' {
+				end = k + 1
+			} else if k-end >= 2*context {
+				break
+			}
+		}
+		from := max(start-context, 0)
+		to := min(end+context, len(edits))
+
+		if result.Len() == 0 {
+			fmt.Fprintf(&result, "--- %s\n+++ %s\n", nameA, nameB)
+		}
+		lineA, lineB := 1, 1
+		for _, e := range edits[:from] {
+			if e.op != '+' {
+				lineA++
+			}
+			if e.op != '-' {
+				lineB++
+			}
+		}
+		countA, countB := 0, 0
+		for _, e := range edits[from:to] {
+			if e.op != '+' {
+				countA++
+			}
+			if e.op != '-' {
+				countB++
+			}
+		}
+		fmt.Fprintf(&result, "@@ -%s +%s @@\n", hunkRange(lineA, countA), hunkRange(lineB, countB))
+		for _, e := range edits[from:to] {
+			result.WriteByte(e.op)
+			result.WriteString(e.line)
+			if !strings.HasSuffix(e.line, "\n") {
+				result.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = to
+	}
+	return result.String()
+}
+
+// hunkRange formats the start and length of one side of a hunk.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// lines joins numbered lines from first to last, each ending in a newline.
+func lines(first, last int, replace map[int]string) string {
+	var result strings.Builder
+	for i := first; i <= last; i++ {
+		if line, ok := replace[i]; ok {
+			result.WriteString(line + "\n")
+		} else {
+			result.WriteString(strconv.Itoa(i) + "\n")
+		}
+	}
+	return result.String()
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{"equal", "A=1\n", "A=1\n", ""},
+		{"both empty", "", "", ""},
+		{"from empty", "", "A=1\nB=2\n", "@@ -0,0 +1,2 @@\n+A=1\n+B=2\n"},
+		{"to empty", "A=1\nB=2\n", "", "@@ -1,2 +0,0 @@\n-A=1\n-B=2\n"},
+		{"change at start", lines(1, 5, nil), lines(1, 5, map[int]string{1: "x"}), "@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n"},
+		{"change at end", lines(1, 8, nil), lines(1, 8, map[int]string{8: "x"}), "@@ -5,4 +5,4 @@\n 5\n 6\n 7\n-8\n+x\n"},
+		{"single lines", "A=1\n", "A=2\n", "@@ -1 +1 @@\n-A=1\n+A=2\n"},
+		{"newline added", "A=1", "A=1\n", "@@ -1 +1 @@\n-A=1\n\\ No newline at end of file\n+A=1\n"},
+		{"newline removed", "A=1\n", "A=1", "@@ -1 +1 @@\n-A=1\n+A=1\n\\ No newline at end of file\n"},
+		{
+			"two hunks",
+			lines(1, 12, nil),
+			lines(1, 12, map[int]string{1: "x", 12: "y"}),
+			"@@ -1,4 +1,4 @@\n-1\n+x\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+y\n",
+		},
+		{
+			"merged hunks",
+			lines(1, 9, nil),
+			lines(1, 9, map[int]string{1: "x", 8: "y"}),
+			"@@ -1,9 +1,9 @@\n-1\n+x\n 2\n 3\n 4\n 5\n 6\n 7\n-8\n+y\n 9\n",
+		},
+		{"insertion", "A=1\nC=3\n", "A=1\nB=2\nC=3\n", "@@ -1,2 +1,3 @@\n A=1\n+B=2\n C=3\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := test.expected
+			if expected != "" {
+				expected = "--- a\n+++ b\n" + expected
+			}
+			if diff := UnifiedDiff("a", "b", test.a, test.b); diff != expected {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", diff, expected)
+			}
+		})
+	}
+}
+
+func TestHunkRange(t *testing.T) {
+	tests := []struct {
+		start, count int
+		expected     string
+	}{
+		{1, 0, "0,0"},
+		{4, 0, "3,0"},
+		{1, 1, "1"},
+		{7, 1, "7"},
+		{1, 3, "1,3"},
+	}
+	for _, test := range tests {
+		if got := hunkRange(test.start, test.count); got != test.expected {
+			t.Errorf("hunkRange(%d, %d) = %q, want %q", test.start, test.count, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+// GetCommand implements `dotenv get [options] KEY`. It prints the value of
+// KEY exactly as loaded, without a trailing newline, and returns 1 if KEY is
+// not defined and no default was given. The default is also used when there
+// is no dotenv file at all.
+func GetCommand(args []string) int {
+	fs := flag.NewFlagSet("get", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dotenv get [options] [--] KEY")
+		fs.PrintDefaults()
+	}
+	var search SearchFlags
+	search.Register(fs)
+	defaultValue := fs.String("default", "", "Value to print if KEY is not defined, instead of failing")
+	positional := ParseArgs(fs, args)
+	if len(positional) != 1 {
+		fs.Usage()
+		return 2
+	}
+	key := positional[0]
+	hasDefault := isFlagSet(fs, "default")
+
+	if hasDefault && len(search.search()) == 0 {
+		Log("No dotenv file found, using the default")
+		fmt.Print(*defaultValue)
+		return 0
+	}
+	envMap, ok := search.Load()
+	if !ok {
+		return 1
+	}
+
+	// Without layering duplicates are kept; the last definition wins
+	for i := len(envMap) - 1; i >= 0; i-- {
+		if envMap[i].Name == key {
+			fmt.Print(envMap[i].Value)
+			return 0
+		}
+	}
+
+	if hasDefault {
+		fmt.Print(*defaultValue)
+		return 0
+	}
+	Error("Variable not defined:", key)
+	return 1
+}
+
+// isFlagSet reports whether the flag called name was given on the command
+// line, which tells an explicitly empty value from the default.
+func isFlagSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
@@ -0,0 +1,58 @@
+package main
+
+import "testing"
+
+func TestGetCommand(t *testing.T) {
+	writeFiles(t, map[string]string{
+		".env": "HOST=localhost\nEMPTY=\nKEY=\"\"\"\nline 1\nline 2\n\"\"\"\nHOST=example.com\n",
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		status   int
+	}{
+		{"present", []string{"HOST"}, "example.com", 0},
+		{"empty", []string{"EMPTY"}, "", 0},
+		{"multiline", []string{"KEY"}, "line 1\nline 2\n", 0},
+		{"missing", []string{"PORT"}, "", 1},
+		{"default", []string{"-default", "3000", "PORT"}, "3000", 0},
+		{"default after key", []string{"PORT", "-default", "3000"}, "3000", 0},
+		{"empty default", []string{"-default=", "PORT"}, "", 0},
+		{"default of defined key", []string{"-default", "x", "HOST"}, "example.com", 0},
+		{"no file", []string{"-f", ".env.missing", "PORT"}, "", 1},
+		{"no file with default", []string{"-f", ".env.missing", "-default", "3000", "PORT"}, "3000", 0},
+		{"no file with empty default", []string{"-f", ".env.missing", "-default=", "PORT"}, "", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, status := captureStdout(t, func() int { return GetCommand(test.args) })
+			if output != test.expected || status != test.status {
+				t.Errorf("GetCommand(%q) printed %q and returned %d, want %q and %d", test.args, output, status, test.expected, test.status)
+			}
+		})
+	}
+}
+
+func TestGetCommandSchemaDefaults(t *testing.T) {
+	writeFiles(t, map[string]string{
+		".env":        "URL=http://localhost:${PORT}\nHOST=example.com\n",
+		".env.schema": `{"PORT": {"type": "port", "default": "3000"}, "HOST": {"default": "localhost"}}`,
+	})
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"URL", "http://localhost:3000"},
+		{"PORT", "3000"},
+		{"HOST", "example.com"},
+	}
+	for _, test := range tests {
+		output, status := captureStdout(t, func() int { return GetCommand([]string{"-schema", ".env.schema", test.key}) })
+		if output != test.expected || status != 0 {
+			t.Errorf("GetCommand(%s) printed %q and returned %d, want %q and 0", test.key, output, status, test.expected)
+		}
+	}
+}
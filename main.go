@@ -0,0 +1,410 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/MeroFuruya/dotenv/dotenv"
+)
+
+type ArrayFlags []string
+
+// String is an implementation of the flag.Value interface
+func (i *ArrayFlags) String() string {
+	return fmt.Sprintf("%v", *i)
+}
+
+// Set is an implementation of the flag.Value interface
+func (i *ArrayFlags) Set(value string) error {
+	*i = append(*i, value)
+	return nil
+}
+
+var quiet bool
+
+// SearchFlags are the flags shared by every command that reads a dotenv file.
+type SearchFlags struct {
+	dir       ArrayFlags
+	name      ArrayFlags
+	recursive bool
+	all       bool
+	env       string
+	schema    string
+
+	loadedSchema *dotenv.Schema
+}
+
+// Register adds the search flags and -q to fs.
+func (s *SearchFlags) Register(fs *flag.FlagSet) {
+	fs.Var(&s.dir, "d", "Directories to search inside (can be specified multiple times) (default: current directory)")
+	fs.Var(&s.name, "f", "Filenames to search for (can be specified multiple times) (default: \".env\")")
+	fs.BoolVar(&s.recursive, "r", false, "Search directories recursively (default: false)")
+	fs.BoolVar(&s.all, "all", false, "Load every file given with -f in order, later files overriding earlier ones (default: false)")
+	fs.StringVar(&s.env, "env", "", "Load .env, .env.local, .env.<env>, .env.<env>.local and the files given with -f in order, later files overriding earlier ones")
+	fs.StringVar(&s.schema, "schema", "", "Schema file whose defaults are added for variables that are not defined")
+	fs.BoolVar(&quiet, "q", false, "Suppress non-error output")
+}
+
+// Layered reports whether -all or -env asked for several files to be merged.
+func (s *SearchFlags) Layered() bool {
+	return s.all || s.env != ""
+}
+
+// Files searches for the dotenv file, or for every matching file when
+// layered. Errors are reported before returning false.
+func (s *SearchFlags) Files() ([]string, bool) {
+	dotenvFiles := s.search()
+	if len(dotenvFiles) == 0 {
+		Error("No dotenv file found")
+		return nil, false
+	}
+	for _, dotenvFile := range dotenvFiles {
+		Log("Using dotenv file:", dotenvFile)
+	}
+	return dotenvFiles, true
+}
+
+// Target returns the file that commands editing a dotenv file should write:
+// the file found by the search, the one with the highest precedence when
+// layered, or else a new file named after the first -f in the first -d.
+func (s *SearchFlags) Target() string {
+	dotenvFiles := s.search()
+	if len(dotenvFiles) == 0 {
+		return filepath.Join(s.dir[0], s.name[0])
+	}
+	return dotenvFiles[len(dotenvFiles)-1]
+}
+
+// search fills in the default directory and names and returns the files
+// found, reporting directories that cannot be read.
+func (s *SearchFlags) search() []string {
+	if len(s.dir) == 0 {
+		s.dir = append(s.dir, ".")
+	}
+	if s.env != "" {
+		// -env is -all with the conventional files, followed by those given
+		// with -f
+		s.name = append(dotenv.EnvFiles(s.env), s.name...)
+		s.all, s.env = true, ""
+	}
+	if len(s.name) == 0 {
+		s.name = append(s.name, ".env")
+	}
+
+	for _, d := range s.dir {
+		if _, err := os.ReadDir(d); err != nil {
+			Error("Error reading directory:", d, err)
+		}
+	}
+
+	if s.Layered() {
+		return dotenv.SearchFiles(s.dir, s.name, s.recursive)
+	}
+	if dotenvFile := dotenv.SearchFile(s.dir, s.name, s.recursive); dotenvFile != "" {
+		return []string{dotenvFile}
+	}
+	return nil
+}
+
+// Load searches for the dotenv file and parses it. With -all or -env every
+// matching file is parsed and the results are merged. With -schema the
+// defaults of the schema are used for variables that are not defined, both
+// in references and in the result. Errors are reported before returning
+// false.
+func (s *SearchFlags) Load() ([]dotenv.Variable, bool) {
+	dotenvFiles, ok := s.Files()
+	if !ok {
+		return nil, false
+	}
+
+	var schema *dotenv.Schema
+	var defaults []dotenv.Variable
+	if s.schema != "" {
+		if schema, ok = s.Schema(); !ok {
+			return nil, false
+		}
+		defaults = schema.ApplyDefaults(nil)
+	}
+
+	envMap, ok := parseFiles(dotenvFiles, defaults)
+	if !ok {
+		return nil, false
+	}
+	if s.Layered() {
+		envMap = dotenv.Merge(envMap)
+		for _, variable := range envMap {
+			Log(fmt.Sprintf("%s from %s:%d", variable.Name, variable.File, variable.Line))
+		}
+	}
+
+	if schema != nil {
+		envMap = schema.ApplyDefaults(envMap)
+	}
+	return envMap, true
+}
+
+// Schema loads the schema file given with -schema, once. Errors are reported
+// before returning false.
+func (s *SearchFlags) Schema() (*dotenv.Schema, bool) {
+	if s.loadedSchema == nil {
+		schema, err := dotenv.LoadSchema(s.schema)
+		if err != nil {
+			Error("Error reading schema file:", err)
+			return nil, false
+		}
+		Log("Using schema file:", s.schema)
+		s.loadedSchema = schema
+	}
+	return s.loadedSchema, true
+}
+
+// parseFiles parses the files in order with a recovering parser, so that all
+// errors in all files are reported at once. References to variables that are
+// not defined see defaults.
+func parseFiles(dotenvFiles []string, defaults []dotenv.Variable) ([]dotenv.Variable, bool) {
+	parser := dotenv.NewParser()
+	parser.SetRecover(true)
+	parser.SetDefaults(defaults)
+
+	var envMap []dotenv.Variable
+	ok := true
+	for _, dotenvFile := range dotenvFiles {
+		variables, err := parser.ParseFile(dotenvFile)
+		if err != nil {
+			ReportLoadError(err)
+			ok = false
+		}
+		envMap = variables
+	}
+	return envMap, ok
+}
+
+// ParseArgs parses args with fs, allowing flags to appear between the
+// positional arguments, which it returns. Everything after "--" is
+// positional, even if it starts with a dash.
+func ParseArgs(fs *flag.FlagSet, args []string) []string {
+	var rest []string
+	for i, arg := range args {
+		if arg == "--" {
+			args, rest = args[:i], args[i+1:]
+			break
+		}
+	}
+
+	var positional []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	return append(positional, rest...)
+}
+
+// WriteFileAtomic replaces filename with data by writing a temporary file in
+// the same directory and renaming it, so that readers never see a partially
+// written file. The mode of an existing file is kept; new files get 0644.
+func WriteFileAtomic(filename string, data []byte) error {
+	if resolved, err := filepath.EvalSymlinks(filename); err == nil {
+		filename = resolved
+	}
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(filename); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(filename), "."+filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil && runtime.GOOS != "windows" {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "run":
+			os.Exit(RunCommand(os.Args[2:]))
+		case "check":
+			os.Exit(CheckCommand(os.Args[2:]))
+		case "diff":
+			os.Exit(DiffCommand(os.Args[2:]))
+		case "fmt":
+			os.Exit(FmtCommand(os.Args[2:]))
+		case "get":
+			os.Exit(GetCommand(os.Args[2:]))
+		case "validate":
+			os.Exit(ValidateCommand(os.Args[2:]))
+		case "verify":
+			os.Exit(VerifyCommand(os.Args[2:]))
+		case "set":
+			os.Exit(SetCommand(os.Args[2:]))
+		case "unset":
+			os.Exit(UnsetCommand(os.Args[2:]))
+		}
+	}
+
+	var search SearchFlags
+	search.Register(flag.CommandLine)
+	var shell string
+	flag.StringVar(&shell, "s", "auto-detect", "Shell or format to generate output for (supported: bash, zsh, fish, powershell, cmd, auto-detect, value, none, json, json-lines, yaml, toml, docker, compose, configmap, secret, k8s, systemd, systemd-dropin, github, gitlab-dotenv, template=TEXT)")
+	var filter string
+	flag.StringVar(&filter, "filter", "", "Only output variables that match this regex pattern")
+	var output OutputFlags
+	output.Register(flag.CommandLine)
+	unload := flag.Bool("unload", false, "Output a script that reverts loading the dotenv file, restoring the values recorded with -snapshot if there are any (default: false)")
+	snapshot := flag.Bool("snapshot", false, "Also set $DOTENV_PREVIOUS to the current values of the variables, so that -unload can restore them after loading (default: false)")
+	flag.Parse()
+	if flag.NArg() > 0 {
+		// A command after the options would otherwise be ignored silently
+		Error(fmt.Sprintf("Unexpected argument %q, commands such as run or set must come before the options", flag.Arg(0)))
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	options, shell, ok := output.Options(shell)
+	if !ok {
+		os.Exit(2)
+	}
+	if *snapshot && *unload {
+		Error("-snapshot cannot be combined with -unload")
+		os.Exit(2)
+	}
+	// Unloading with a snapshot restores the variables recorded in it and
+	// needs no dotenv file
+	var envMap []dotenv.Variable
+	if _, found := os.LookupEnv(dotenv.SnapshotVariable); !*unload || !found {
+		if envMap, ok = search.Load(); !ok {
+			os.Exit(1)
+		}
+	}
+
+	if shell == "auto-detect" {
+		shell = dotenv.DetectShell()
+		Log("Auto-detected shell:", shell)
+	}
+	if *snapshot && shell != "" && dotenv.TransformToUnsetSyntax("NAME", shell) == "" {
+		Error("-snapshot only works with shell output")
+		os.Exit(2)
+	}
+
+	var selected []dotenv.Variable
+	for _, variable := range envMap {
+		if filter != "" {
+			matched, err := MatchRegex(filter, variable.Name)
+			if err != nil {
+				Error("Invalid regex pattern:", filter, err)
+				return
+			}
+			if !matched {
+				continue
+			}
+		}
+		selected = append(selected, variable)
+	}
+
+	var rendered string
+	var err error
+	if *unload {
+		rendered, err = dotenv.RenderUnload(selected, os.Environ(), shell)
+	} else {
+		variables := selected
+		if *snapshot && len(selected) > 0 {
+			variables = append(variables, dotenv.Snapshot(selected, os.Environ(), shell))
+		}
+		rendered, err = dotenv.RenderWithOptions(variables, shell, options)
+	}
+	if err != nil {
+		if shell == "" {
+			Error("Could not detect the shell, use -s to select one")
+		} else {
+			Error("Error rendering output:", err)
+		}
+		os.Exit(1)
+	}
+	if written, status := output.Write(shell, rendered, selected, options); written {
+		os.Exit(status)
+	}
+	fmt.Print(rendered)
+}
+
+func Log(message ...any) {
+	if !quiet {
+		fmt.Fprintln(os.Stderr, append([]any{"[Log]"}, message...)...)
+	}
+}
+
+func Error(message ...any) {
+	fmt.Fprintln(os.Stderr, append([]any{"[Error]"}, message...)...)
+}
+
+// ReportLoadError prints an error from reading a dotenv file. Parse errors
+// are shown compiler-style with the offending line and a caret under the
+// position of the error.
+func ReportLoadError(err error) {
+	var errs dotenv.ErrorList
+	var parseErr *dotenv.ParseError
+	switch {
+	case errors.As(err, &errs):
+		for _, parseErr := range errs {
+			PrintParseError(parseErr)
+		}
+	case errors.As(err, &parseErr):
+		PrintParseError(parseErr)
+	default:
+		Error("Error reading dotenv file:", err)
+	}
+}
+
+// PrintParseError prints parseErr followed by its source line and a caret.
+func PrintParseError(parseErr *dotenv.ParseError) {
+	fmt.Fprintln(os.Stderr, parseErr)
+	fmt.Fprintln(os.Stderr, parseErr.Snippet)
+	var caret strings.Builder
+	for i, char := range parseErr.Snippet {
+		if i >= parseErr.Column-1 {
+			break
+		}
+		if char == '\t' {
+			caret.WriteRune('\t')
+		} else {
+			caret.WriteRune(' ')
+		}
+	}
+	caret.WriteRune('^')
+	fmt.Fprintln(os.Stderr, caret.String())
+}
+
+func MatchRegex(pattern, str string) (bool, error) {
+	matched, err := regexp.MatchString(pattern, str)
+	if err != nil {
+		return false, err
+	}
+	return matched, nil
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// TestMain lets the test binary stand in for a child process: with
+// DOTENV_TEST_MAIN set it runs dotenv itself, with DOTENV_TEST_EXIT it
+// exits with that status, with DOTENV_TEST_SIGNAL it kills itself with that
+// signal, and with DOTENV_TEST_WAIT it creates that file and waits to be
+// killed, instead of running the tests.
+func TestMain(m *testing.M) {
+	if os.Getenv("DOTENV_TEST_MAIN") != "" {
+		main()
+		os.Exit(0)
+	}
+	if status := os.Getenv("DOTENV_TEST_EXIT"); status != "" {
+		code, _ := strconv.Atoi(status)
+		os.Exit(code)
+	}
+	if signal := os.Getenv("DOTENV_TEST_SIGNAL"); signal != "" {
+		number, _ := strconv.Atoi(signal)
+		process, _ := os.FindProcess(os.Getpid())
+		process.Signal(syscall.Signal(number))
+		time.Sleep(time.Minute)
+	}
+	if ready := os.Getenv("DOTENV_TEST_WAIT"); ready != "" {
+		os.WriteFile(ready, nil, 0o644)
+		time.Sleep(time.Minute)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// chdir changes into dir for the rest of the test.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+	previous, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(previous) })
+}
+
+// writeFiles creates the files in a new temporary directory, changes into it
+// and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	chdir(t, dir)
+	return dir
+}
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		positional []string
+		verbose    bool
+	}{
+		{"flags first", []string{"-v", "KEY", "value"}, []string{"KEY", "value"}, true},
+		{"interleaved", []string{"KEY", "-v", "value"}, []string{"KEY", "value"}, true},
+		{"flags last", []string{"KEY", "value", "-v"}, []string{"KEY", "value"}, true},
+		{"double dash", []string{"KEY", "--", "-v", "--"}, []string{"KEY", "-v", "--"}, false},
+		{"flag before double dash", []string{"-v", "--", "-KEY"}, []string{"-KEY"}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			verbose := fs.Bool("v", false, "")
+			positional := ParseArgs(fs, test.args)
+			if !reflect.DeepEqual(positional, test.positional) {
+				t.Errorf("ParseArgs(%q) = %q, want %q", test.args, positional, test.positional)
+			}
+			if *verbose != test.verbose {
+				t.Errorf("ParseArgs(%q) set -v to %v, want %v", test.args, *verbose, test.verbose)
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("new file", func(t *testing.T) {
+		filename := filepath.Join(dir, "new.env")
+		if err := WriteFileAtomic(filename, []byte("A=1\n")); err != nil {
+			t.Fatalf("WriteFileAtomic() error = %v", err)
+		}
+		checkFile(t, filename, "A=1\n")
+		if info, _ := os.Stat(filename); runtime.GOOS != "windows" && info.Mode().Perm() != 0o644 {
+			t.Errorf("mode = %v, want 0644", info.Mode().Perm())
+		}
+	})
+
+	t.Run("keeps mode", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("Windows has no permission bits")
+		}
+		filename := filepath.Join(dir, "private.env")
+		if err := os.WriteFile(filename, []byte("A=1\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteFileAtomic(filename, []byte("A=2\n")); err != nil {
+			t.Fatalf("WriteFileAtomic() error = %v", err)
+		}
+		checkFile(t, filename, "A=2\n")
+		if info, _ := os.Stat(filename); info.Mode().Perm() != 0o600 {
+			t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+		}
+	})
+
+	t.Run("symlink", func(t *testing.T) {
+		target := filepath.Join(dir, "target.env")
+		link := filepath.Join(dir, "link.env")
+		if err := os.WriteFile(target, []byte("A=1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(target, link); err != nil {
+			t.Skip("cannot create symlinks:", err)
+		}
+		if err := WriteFileAtomic(link, []byte("A=2\n")); err != nil {
+			t.Fatalf("WriteFileAtomic() error = %v", err)
+		}
+		checkFile(t, target, "A=2\n")
+		if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+			t.Errorf("%s is no longer a symlink", link)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// A file cannot replace a directory
+		filename := filepath.Join(dir, "directory")
+		if err := os.Mkdir(filename, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := WriteFileAtomic(filename, []byte("A=1\n")); err == nil {
+			t.Errorf("WriteFileAtomic() replaced a directory")
+		}
+		if leftovers, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(leftovers) > 0 {
+			t.Errorf("temporary files left behind: %q", leftovers)
+		}
+	})
+}
+
+// checkFile fails the test if filename does not contain expected.
+func checkFile(t *testing.T, filename, expected string) {
+	t.Helper()
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != expected {
+		t.Errorf("%s = %q, want %q", filename, content, expected)
+	}
+}
+
+// captureStdout returns what run prints to standard output and its result.
+func captureStdout(t *testing.T, run func() int) (string, int) {
+	t.Helper()
+	file, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	stdout := os.Stdout
+	os.Stdout = file
+	status := run()
+	os.Stdout = stdout
+
+	output, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(output), status
+}
+
+// runMain runs dotenv with args in a child process and returns its standard
+// output, standard error and exit status. env is added to the environment.
+func runMain(t *testing.T, env []string, args ...string) (stdout, stderr string, status int) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(append(os.Environ(), "DOTENV_TEST_MAIN=1"), env...)
+	var outBuffer, errBuffer strings.Builder
+	cmd.Stdout, cmd.Stderr = &outBuffer, &errBuffer
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		status = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return outBuffer.String(), errBuffer.String(), status
+}
+
+func TestUnload(t *testing.T) {
+	writeFiles(t, map[string]string{".env": "UNLOAD_HOST=localhost\nUNLOAD_PORT=80\n"})
+
+	stdout, stderr, status := runMain(t, []string{"UNLOAD_HOST=old"}, "-s", "bash")
+	if status != 0 || strings.Contains(stdout, "DOTENV_PREVIOUS") {
+		t.Fatalf("dotenv -s bash = %q, %q, %d, want the variables without DOTENV_PREVIOUS", stdout, stderr, status)
+	}
+
+	stdout, stderr, status = runMain(t, []string{"UNLOAD_HOST=old"}, "-s", "bash", "-snapshot")
+	if status != 0 {
+		t.Fatalf("dotenv exited with %d: %s", status, stderr)
+	}
+	lines := strings.Split(stdout, "\n")
+	snapshot, found := strings.CutPrefix(lines[len(lines)-1], "export DOTENV_PREVIOUS=")
+	if len(lines) != 3 || !found {
+		t.Fatalf("dotenv -snapshot printed %q, want the variables and DOTENV_PREVIOUS", stdout)
+	}
+	loaded := []string{"UNLOAD_HOST=localhost", "UNLOAD_PORT=80"}
+
+	t.Run("after loading", func(t *testing.T) {
+		// The snapshot is enough, even where there is no dotenv file
+		chdir(t, t.TempDir())
+		env := append(loaded, "DOTENV_PREVIOUS="+strings.Trim(snapshot, "'"))
+		stdout, stderr, status := runMain(t, env, "-s", "bash", "-unload")
+		if status != 0 {
+			t.Fatalf("dotenv exited with %d: %s", status, stderr)
+		}
+		if expected := "export UNLOAD_HOST='old'\nunset UNLOAD_PORT\nunset DOTENV_PREVIOUS"; stdout != expected {
+			t.Errorf("dotenv -unload printed %q, want %q", stdout, expected)
+		}
+	})
+
+	t.Run("before loading", func(t *testing.T) {
+		stdout, stderr, status := runMain(t, []string{"UNLOAD_HOST=old"}, "-s", "bash", "-unload")
+		if status != 0 {
+			t.Fatalf("dotenv exited with %d: %s", status, stderr)
+		}
+		if expected := "export UNLOAD_HOST='old'\nunset UNLOAD_PORT"; stdout != expected {
+			t.Errorf("dotenv -unload printed %q, want %q", stdout, expected)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		for _, args := range [][]string{{"-s", "bash", "-snapshot", "-unload"}, {"-s", "json", "-snapshot"}} {
+			if _, _, status := runMain(t, nil, args...); status != 2 {
+				t.Errorf("dotenv %v exited with %d, want 2", args, status)
+			}
+		}
+	})
+
+	t.Run("loaded without snapshot", func(t *testing.T) {
+		stdout, stderr, status := runMain(t, loaded, "-s", "bash", "-unload")
+		if status != 1 || stdout != "" || !strings.Contains(stderr, "DOTENV_PREVIOUS") {
+			t.Errorf("dotenv -unload = %q, %q, %d, want an error naming DOTENV_PREVIOUS and status 1", stdout, stderr, status)
+		}
+	})
+}
+
+func TestUnexpectedArguments(t *testing.T) {
+	writeFiles(t, map[string]string{".env": "KEY=value\n"})
+
+	for _, args := range [][]string{
+		{"-f", ".env", "run", "--", "sh", "-c", "exit 7"},
+		{"-q", "set", "KEY", "changed"},
+	} {
+		stdout, stderr, status := runMain(t, nil, args...)
+		if status != 2 || stdout != "" || !strings.Contains(stderr, "Unexpected argument") {
+			t.Errorf("dotenv %v = %q, %q, %d, want a usage error and status 2", args, stdout, stderr, status)
+		}
+	}
+	checkFile(t, ".env", "KEY=value\n")
+}
+
+func TestEnvWithFiles(t *testing.T) {
+	writeFiles(t, map[string]string{
+		".env":       "A=1\nB=1\nC=1\n",
+		".env.dev":   "B=2\nC=2\n",
+		"custom.env": "C=3\n",
+	})
+
+	stdout, stderr, status := runMain(t, nil, "-env", "dev", "-f", "custom.env", "-s", "json")
+	if status != 0 {
+		t.Fatalf("dotenv exited with %d: %s", status, stderr)
+	}
+	if expected := "{\n  \"A\": \"1\",\n  \"B\": \"2\",\n  \"C\": \"3\"\n}\n"; stdout != expected {
+		t.Errorf("dotenv -env dev -f custom.env printed %q, want %q", stdout, expected)
+	}
+}
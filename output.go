@@ -0,0 +1,189 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/MeroFuruya/dotenv/dotenv"
+)
+
+// defaultDropInDir holds the drop-in directories of system units.
+const defaultDropInDir = "/etc/systemd/system"
+
+// OutputFlags are the flags configuring the output formats that need more
+// than the variables.
+type OutputFlags struct {
+	name          string
+	namespace     string
+	labels        ArrayFlags
+	stringData    bool
+	secretPattern string
+	unit          string
+	dropInDir     string
+	template      string
+	githubEnv     bool
+}
+
+// Register adds the output flags to fs.
+func (o *OutputFlags) Register(fs *flag.FlagSet) {
+	fs.StringVar(&o.name, "name", "", "Name of Kubernetes manifests (configmap, secret, k8s)")
+	fs.StringVar(&o.namespace, "namespace", "", "Namespace of Kubernetes manifests")
+	fs.Var(&o.labels, "label", "Label key=value added to Kubernetes manifests (can be specified multiple times)")
+	fs.BoolVar(&o.stringData, "string-data", false, "Write Secret values as plain text in stringData instead of base64 (default: false)")
+	fs.StringVar(&o.secretPattern, "secret-pattern", "", "Regex pattern of the names that go into the Secret in k8s output and are masked in github output (default: common secret names such as *_PASSWORD, *_TOKEN or *_KEY)")
+	fs.StringVar(&o.unit, "unit", "", "Write systemd-dropin output to a drop-in file of this systemd unit instead of printing it")
+	fs.StringVar(&o.dropInDir, "dropin-dir", defaultDropInDir, "Directory containing the drop-in directories of systemd units")
+	fs.BoolVar(&o.githubEnv, "github-env", false, "Append github output to the $GITHUB_ENV file and print ::add-mask:: commands for secret values instead (default: false)")
+	fs.StringVar(&o.template, "template", "", "Render the variables with this Go text/template file, same as -s template")
+}
+
+// Options converts the flags to dotenv.RenderOptions for format, the value of
+// -s, and returns the format to render. The template flags select the
+// template format: -s template=TEXT is changed to template, and -template
+// FILE makes it the default. Errors are reported before returning false.
+func (o *OutputFlags) Options(format string) (dotenv.RenderOptions, string, bool) {
+	options := dotenv.RenderOptions{
+		Name:       o.name,
+		Namespace:  o.namespace,
+		StringData: o.stringData,
+	}
+
+	if len(o.labels) > 0 {
+		options.Labels = make(map[string]string, len(o.labels))
+		for _, label := range o.labels {
+			key, value, found := strings.Cut(label, "=")
+			if !found || key == "" {
+				Error(fmt.Sprintf("Invalid label %q, expected key=value", label))
+				return options, format, false
+			}
+			options.Labels[key] = value
+		}
+	}
+
+	if o.secretPattern != "" {
+		pattern, err := regexp.Compile(o.secretPattern)
+		if err != nil {
+			Error("Invalid regex pattern:", o.secretPattern, err)
+			return options, format, false
+		}
+		options.SecretPattern = pattern
+	}
+
+	if text, inline := strings.CutPrefix(format, "template="); inline {
+		if o.template != "" {
+			Error("Use either -template or -s template=..., not both")
+			return options, format, false
+		}
+		format = "template"
+		template, err := dotenv.ParseTemplate("template", text)
+		if err != nil {
+			Error("Invalid template:", err)
+			return options, format, false
+		}
+		options.Template = template
+	} else if o.template != "" {
+		if format != "auto-detect" && format != "template" {
+			Error("-template cannot be combined with -s", format)
+			return options, format, false
+		}
+		format = "template"
+		text, err := os.ReadFile(o.template)
+		if err != nil {
+			Error("Error reading template file:", err)
+			return options, format, false
+		}
+		template, err := dotenv.ParseTemplate(filepath.Base(o.template), string(text))
+		if err != nil {
+			Error("Invalid template file:", err)
+			return options, format, false
+		}
+		options.Template = template
+	}
+
+	switch {
+	case o.unit != "" && format != "systemd-dropin":
+		Error("-unit only works with -s systemd-dropin")
+		return options, format, false
+	case o.dropInDir != defaultDropInDir && o.unit == "":
+		Error("-dropin-dir only works with -unit")
+		return options, format, false
+	case o.githubEnv && format != "github":
+		Error("-github-env only works with -s github")
+		return options, format, false
+	case o.githubEnv && os.Getenv("GITHUB_ENV") == "":
+		Error("-github-env needs $GITHUB_ENV, which GitHub Actions sets in every step")
+		return options, format, false
+	}
+	return options, format, true
+}
+
+// Write writes the rendered output of format to the file selected by the
+// flags, if any, and reports whether it did. Errors are reported and make
+// the returned status non-zero. Output for GitHub Actions that goes to
+// standard output is preceded by the masks for secret values on standard
+// error, where the runner reads workflow commands as well, so that they do
+// not end up in $GITHUB_ENV when the output is redirected there.
+func (o *OutputFlags) Write(format, rendered string, variables []dotenv.Variable, options dotenv.RenderOptions) (written bool, status int) {
+	switch {
+	case format == "systemd-dropin" && o.unit != "":
+		return true, o.writeDropIn(rendered)
+	case format == "github" && o.githubEnv:
+		return true, writeGitHubEnv(os.Getenv("GITHUB_ENV"), rendered, variables, options)
+	case format == "github":
+		fmt.Fprint(os.Stderr, dotenv.GitHubMasks(variables, options))
+	}
+	return false, 0
+}
+
+// writeDropIn writes rendered to the drop-in file of the unit.
+func (o *OutputFlags) writeDropIn(rendered string) int {
+	if strings.ContainsAny(o.unit, `/\`) {
+		Error("Invalid unit name:", o.unit)
+		return 2
+	}
+	unit := o.unit
+	if !strings.Contains(unit, ".") {
+		unit += ".service"
+	}
+	dir := filepath.Join(o.dropInDir, unit+".d")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		Error("Error creating drop-in directory:", err)
+		return 1
+	}
+	dropIn := filepath.Join(dir, "dotenv.conf")
+	if err := WriteFileAtomic(dropIn, []byte(rendered)); err != nil {
+		Error("Error writing drop-in file:", err)
+		return 1
+	}
+	Log("Wrote drop-in file:", dropIn)
+	Log("Run `systemctl daemon-reload` to apply it")
+	return 0
+}
+
+// writeGitHubEnv appends rendered to the $GITHUB_ENV file of a GitHub
+// Actions step. The masks for secret values go to standard output, where
+// the runner reads workflow commands, and never into the file. They are
+// printed first, so the values are hidden before later steps can log them.
+func writeGitHubEnv(githubEnv, rendered string, variables []dotenv.Variable, options dotenv.RenderOptions) int {
+	fmt.Print(dotenv.GitHubMasks(variables, options))
+
+	file, err := os.OpenFile(githubEnv, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		Error("Error opening $GITHUB_ENV:", err)
+		return 1
+	}
+	_, err = file.WriteString(rendered)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		Error("Error writing $GITHUB_ENV:", err)
+		return 1
+	}
+	Log("Appended variables to $GITHUB_ENV:", githubEnv)
+	return 0
+}
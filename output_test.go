@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSystemdDropIn(t *testing.T) {
+	dir := writeFiles(t, map[string]string{".env": "HOST=localhost\nGREETING=100% \"hi\"\n"})
+	dropInDir := filepath.Join(dir, "system")
+
+	_, stderr, status := runMain(t, nil, "-s", "systemd-dropin", "-unit", "app", "-dropin-dir", dropInDir)
+	if status != 0 {
+		t.Fatalf("dotenv exited with %d: %s", status, stderr)
+	}
+	checkFile(t, filepath.Join(dropInDir, "app.service.d", "dotenv.conf"),
+		"[Service]\nEnvironment=\"HOST=localhost\"\nEnvironment=\"GREETING=100%% \\\"hi\\\"\"\n")
+}
+
+func TestOutputFlagsRejected(t *testing.T) {
+	writeFiles(t, map[string]string{".env": "HOST=localhost\n"})
+
+	tests := []struct {
+		name  string
+		args  []string
+		error string
+	}{
+		{"unit without systemd-dropin", []string{"-s", "json", "-unit", "app"}, "-unit only works with -s systemd-dropin"},
+		{"dropin-dir without unit", []string{"-s", "systemd-dropin", "-dropin-dir", "/tmp"}, "-dropin-dir only works with -unit"},
+		{"unit with a path", []string{"-s", "systemd-dropin", "-unit", "../app"}, "Invalid unit name"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr, status := runMain(t, nil, test.args...)
+			if status != 2 || stdout != "" || !strings.Contains(stderr, test.error) {
+				t.Errorf("dotenv %q exited with %d, printed %q and %q, want 2 and an error containing %q", test.args, status, stdout, stderr, test.error)
+			}
+		})
+	}
+}
+
+func TestGitHubOutput(t *testing.T) {
+	dir := writeFiles(t, map[string]string{".env": "HOST=localhost\nAPI_TOKEN=s3cr3t\n"})
+	githubEnv := filepath.Join(dir, "github_env")
+	env := []string{"GITHUB_ENV=" + githubEnv}
+
+	t.Run("stdout", func(t *testing.T) {
+		stdout, stderr, status := runMain(t, env, "-s", "github")
+		if status != 0 {
+			t.Fatalf("dotenv exited with %d: %s", status, stderr)
+		}
+		if !strings.HasPrefix(stdout, "HOST<<") || strings.Contains(stdout, "::add-mask::") {
+			t.Errorf("dotenv -s github printed %q, want only the variables", stdout)
+		}
+		if !strings.Contains(stderr, "::add-mask::s3cr3t\n") {
+			t.Errorf("dotenv -s github wrote %q to stderr, want the mask", stderr)
+		}
+		if _, err := os.Stat(githubEnv); !os.IsNotExist(err) {
+			t.Errorf("dotenv -s github wrote to $GITHUB_ENV")
+		}
+	})
+
+	t.Run("github-env", func(t *testing.T) {
+		if err := os.WriteFile(githubEnv, []byte("EARLIER=1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		stdout, stderr, status := runMain(t, env, "-s", "github", "-github-env")
+		if status != 0 {
+			t.Fatalf("dotenv exited with %d: %s", status, stderr)
+		}
+		if stdout != "::add-mask::s3cr3t\n" {
+			t.Errorf("dotenv -github-env printed %q, want only the mask", stdout)
+		}
+
+		content, err := os.ReadFile(githubEnv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		written := string(content)
+		if !strings.HasPrefix(written, "EARLIER=1\nHOST<<") || !strings.Contains(written, "\ns3cr3t\n") || strings.Contains(written, "::") {
+			t.Errorf("$GITHUB_ENV = %q, want the variables appended without masks", written)
+		}
+	})
+
+	t.Run("github-env errors", func(t *testing.T) {
+		tests := []struct {
+			env   string
+			args  []string
+			error string
+		}{
+			{"GITHUB_ENV=" + githubEnv, []string{"-s", "json", "-github-env"}, "-github-env only works with -s github"},
+			{"GITHUB_ENV=", []string{"-s", "github", "-github-env"}, "-github-env needs $GITHUB_ENV"},
+		}
+		for _, test := range tests {
+			_, stderr, status := runMain(t, []string{test.env}, test.args...)
+			if status != 2 || !strings.Contains(stderr, test.error) {
+				t.Errorf("dotenv %q exited with %d and printed %q, want 2 and an error containing %q", test.args, status, stderr, test.error)
+			}
+		}
+	})
+}
+
+func TestTemplateOutput(t *testing.T) {
+	writeFiles(t, map[string]string{
+		".env":       "HOST=localhost\nPORT=3000\n",
+		"names.tmpl": "{{range .}}{{lower .Name}} from {{base .File}}:{{.Line}}\n{{end}}",
+		"bad.tmpl":   "{{range .}",
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		status   int
+		error    string
+	}{
+		{"inline", []string{"-s", "template={{range .}}{{.Name}}={{json .Value}};{{end}}"}, `HOST="localhost";PORT="3000";`, 0, ""},
+		{"file", []string{"-template", "names.tmpl"}, "host from .env:1\nport from .env:2\n", 0, ""},
+		{"file with -s template", []string{"-s", "template", "-template", "names.tmpl"}, "host from .env:1\nport from .env:2\n", 0, ""},
+		{"invalid inline", []string{"-s", "template={{range .}"}, "", 2, "Invalid template:"},
+		{"invalid file", []string{"-template", "bad.tmpl"}, "", 2, "Invalid template file:"},
+		{"missing file", []string{"-template", "missing.tmpl"}, "", 2, "Error reading template file:"},
+		{"both", []string{"-s", "template={{.}}", "-template", "names.tmpl"}, "", 2, "Use either -template or -s template="},
+		{"other format", []string{"-s", "json", "-template", "names.tmpl"}, "", 2, "-template cannot be combined with -s json"},
+		{"no template", []string{"-s", "template"}, "", 1, "a template is required"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stdout, stderr, status := runMain(t, nil, test.args...)
+			if stdout != test.expected || status != test.status || !strings.Contains(stderr, test.error) {
+				t.Errorf("dotenv %q exited with %d and printed %q and %q, want %d, %q and an error containing %q", test.args, status, stdout, stderr, test.status, test.expected, test.error)
+			}
+		})
+	}
+}
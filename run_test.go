@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunCommandExitCodes(t *testing.T) {
+	writeFiles(t, map[string]string{".env": "GREETING=hello\n", "script.sh": "#!/bin/sh\n"})
+
+	tests := []struct {
+		name     string
+		exit     string
+		signal   string
+		args     []string
+		expected int
+	}{
+		{name: "success", exit: "0", args: []string{os.Args[0]}, expected: 0},
+		{name: "exit code", exit: "3", args: []string{os.Args[0]}, expected: 3},
+		{name: "missing binary", args: []string{"dotenv-test-missing-binary"}, expected: 127},
+		{name: "not executable", args: []string{"./script.sh"}, expected: 126},
+		{name: "signal", signal: "15", args: []string{os.Args[0]}, expected: 128 + 15},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.signal != "" && runtime.GOOS == "windows" {
+				t.Skip("processes cannot be killed by signals on Windows")
+			}
+			t.Setenv("DOTENV_TEST_EXIT", test.exit)
+			t.Setenv("DOTENV_TEST_SIGNAL", test.signal)
+			if status := RunCommand(append([]string{"--"}, test.args...)); status != test.expected {
+				t.Errorf("RunCommand(%q) = %d, want %d", test.args, status, test.expected)
+			}
+		})
+	}
+}
+
+func TestRunCommandForwardsSignals(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("processes cannot be killed by signals on Windows")
+	}
+	dir := writeFiles(t, map[string]string{".env": "GREETING=hello\n"})
+	ready := filepath.Join(dir, "ready")
+	t.Setenv("DOTENV_TEST_WAIT", ready)
+
+	go func() {
+		for {
+			if _, err := os.Stat(ready); err == nil {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		// RunCommand catches the signal and passes it on to the child
+		process, _ := os.FindProcess(os.Getpid())
+		process.Signal(syscall.SIGTERM)
+	}()
+
+	if status := RunCommand([]string{"--", os.Args[0]}); status != 128+int(syscall.SIGTERM) {
+		t.Errorf("RunCommand() = %d, want %d", status, 128+int(syscall.SIGTERM))
+	}
+}
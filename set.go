@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/MeroFuruya/dotenv/dotenv"
+)
+
+// SetCommand implements `dotenv set [options] KEY VALUE`. It changes the
+// value of KEY in the dotenv file found by the search flags, or appends it,
+// creating the file if necessary.
+func SetCommand(args []string) int {
+	fs := flag.NewFlagSet("set", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dotenv set [options] [--] KEY VALUE")
+		fs.PrintDefaults()
+	}
+	var search SearchFlags
+	search.Register(fs)
+	positional := ParseArgs(fs, args)
+	if len(positional) != 2 {
+		fs.Usage()
+		return 2
+	}
+	key, value := positional[0], positional[1]
+	if !dotenv.IsValidName(key) {
+		Error("Invalid variable name:", key)
+		return 2
+	}
+
+	return editDocument(&search, true, func(doc *dotenv.Document) bool {
+		doc.Set(key, value)
+		return true
+	})
+}
+
+// UnsetCommand implements `dotenv unset [options] KEY...`. It removes every
+// definition of the keys from the dotenv file found by the search flags.
+func UnsetCommand(args []string) int {
+	fs := flag.NewFlagSet("unset", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dotenv unset [options] [--] KEY...")
+		fs.PrintDefaults()
+	}
+	var search SearchFlags
+	search.Register(fs)
+	keys := ParseArgs(fs, args)
+	if len(keys) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	return editDocument(&search, false, func(doc *dotenv.Document) bool {
+		changed := false
+		for _, key := range keys {
+			if doc.Unset(key) {
+				changed = true
+			} else {
+				Log("Variable not defined:", key)
+			}
+		}
+		return changed
+	})
+}
+
+// editDocument reads the target file of search into a Document, applies edit
+// and writes the file back if edit reports a change. A missing file is
+// treated as empty if create is set.
+func editDocument(search *SearchFlags, create bool, edit func(doc *dotenv.Document) bool) int {
+	dotenvFile := search.Target()
+	Log("Using dotenv file:", dotenvFile)
+
+	file, err := os.Open(dotenvFile)
+	if errors.Is(err, fs.ErrNotExist) && create {
+		Log("Creating dotenv file:", dotenvFile)
+	} else if err != nil {
+		Error("Error reading dotenv file:", err)
+		return 1
+	}
+
+	doc := &dotenv.Document{}
+	if file != nil {
+		doc, err = dotenv.ParseDocument(file)
+		file.Close()
+		if err != nil {
+			Error("Error reading dotenv file:", err)
+			return 1
+		}
+	}
+
+	if !edit(doc) {
+		return 0
+	}
+	if err := WriteFileAtomic(dotenvFile, []byte(doc.String())); err != nil {
+		Error("Error writing dotenv file:", err)
+		return 1
+	}
+	return 0
+}
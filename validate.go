@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+// ValidateCommand implements `dotenv validate [options]`. It checks the
+// variables loaded by the search flags against the schema (.env.schema by
+// default) and returns 1 if any of them does not match.
+func ValidateCommand(args []string) int {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dotenv validate [options]")
+		fs.PrintDefaults()
+	}
+	var search SearchFlags
+	search.Register(fs)
+	fs.Parse(args)
+	if search.schema == "" {
+		search.schema = ".env.schema"
+	}
+
+	schema, ok := search.Schema()
+	if !ok {
+		return 1
+	}
+	envMap, ok := search.Load()
+	if !ok {
+		return 1
+	}
+
+	errs := schema.Validate(envMap)
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	if len(errs) > 0 {
+		return 1
+	}
+	Log("All variables match the schema")
+	return 0
+}
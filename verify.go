@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MeroFuruya/dotenv/dotenv"
+)
+
+// VerifyCommand implements `dotenv verify [options] [file...]`. It checks
+// the given files, or the dotenv file found by the search flags, against an
+// example file and returns 1 if a documented key is missing or empty.
+func VerifyCommand(args []string) int {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: dotenv verify [options] [file...]")
+		fs.PrintDefaults()
+	}
+	var search SearchFlags
+	search.Register(fs)
+	example := fs.String("example", ".env.example", "Example file listing the required keys")
+	fs.Parse(args)
+
+	exampleVariables, ok := loadExample(*example)
+	if !ok {
+		return 1
+	}
+
+	var variables []dotenv.Variable
+	if dotenvFiles := fs.Args(); len(dotenvFiles) > 0 {
+		variables, ok = parseFiles(dotenvFiles, nil)
+	} else {
+		variables, ok = search.Load()
+	}
+	if !ok {
+		return 1
+	}
+
+	status := 0
+	for _, mismatch := range dotenv.Verify(exampleVariables, variables) {
+		location := fmt.Sprintf("%s:%d", mismatch.Variable.File, mismatch.Variable.Line)
+		switch mismatch.Kind {
+		case dotenv.Missing:
+			fmt.Printf("%s: %s is documented but not defined\n", location, mismatch.Name)
+			status = 1
+		case dotenv.Empty:
+			fmt.Printf("%s: %s is required but empty\n", location, mismatch.Name)
+			status = 1
+		case dotenv.Undocumented:
+			fmt.Printf("%s: %s is not documented in %s (warning)\n", location, mismatch.Name, *example)
+		}
+	}
+
+	if status == 0 {
+		Log("All documented keys are set")
+	}
+	return status
+}
+
+// loadExample reads the keys of the example file and where they are
+// defined. Values are not interpolated, since only the keys matter, so
+// references such as ${DB_HOST:?required} are fine. Errors are reported
+// before returning false.
+func loadExample(filename string) ([]dotenv.Variable, bool) {
+	file, err := os.Open(filename)
+	if err != nil {
+		Error("Error reading example file:", err)
+		return nil, false
+	}
+	defer file.Close()
+	doc, err := dotenv.ParseDocument(file)
+	if err != nil {
+		Error("Error reading example file:", err)
+		return nil, false
+	}
+
+	var variables []dotenv.Variable
+	ok := true
+	line := 1
+	for _, node := range doc.Nodes {
+		switch node := node.(type) {
+		case *dotenv.Entry:
+			variables = append(variables, dotenv.Variable{Name: node.Key, Value: node.RawValue, File: filename, Line: line})
+		case *dotenv.Unparsed:
+			Error(fmt.Sprintf("%s:%d: cannot parse line: %s", filename, line, strings.TrimRight(node.Raw, "\r\n")))
+			ok = false
+		}
+		line += strings.Count(node.Text(), "\n")
+	}
+	return variables, ok
+}
@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestVerifyCommand(t *testing.T) {
+	writeFiles(t, map[string]string{
+		".env.example": "# Database\nDB_URL=${DB_HOST:?required}\nKEY=\"\"\"\n...\n\"\"\"\nHOST=\n",
+		"complete.env": "DB_URL=postgres://db\nKEY=abc\nHOST=localhost\n",
+		"partial.env":  "KEY=\nHOST=localhost\nDEBUG=true\n",
+		"db.env":       "DB_URL=postgres://db\nKEY=abc\n",
+		"bad.example":  "HOST=\"localhost\n",
+	})
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		status   int
+	}{
+		{"complete", []string{"complete.env"}, "", 0},
+		{
+			"partial",
+			[]string{"partial.env"},
+			".env.example:2: DB_URL is documented but not defined\n" +
+				"partial.env:1: KEY is required but empty\n" +
+				"partial.env:3: DEBUG is not documented in .env.example (warning)\n",
+			1,
+		},
+		{"after multiline value", []string{"db.env"}, ".env.example:6: HOST is documented but not defined\n", 1},
+		{"missing example", []string{"-example", "missing.example", "complete.env"}, "", 1},
+		{"invalid example", []string{"-example", "bad.example", "complete.env"}, "", 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			output, status := captureStdout(t, func() int { return VerifyCommand(test.args) })
+			if output != test.expected || status != test.status {
+				t.Errorf("VerifyCommand(%q) printed %q and returned %d, want %q and %d", test.args, output, status, test.expected, test.status)
+			}
+		})
+	}
+}